@@ -0,0 +1,68 @@
+package main
+
+import (
+	"birthday-service/internal/importer"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const empUsage = "usage: app emp import [--dry-run] [--format csv|xlsx] [--date-format L] [--{name,birthday,external-id,email}-column H] file"
+
+func runEmp(ctx context.Context, repos repositories, args []string) error {
+	if len(args) == 0 || args[0] != "import" {
+		return errors.New(empUsage)
+	}
+
+	mapping := importer.DefaultMapping()
+	flags := flag.NewFlagSet("emp import", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "validate and report without saving")
+	format := flags.String("format", "", "file format, detected from the extension by default")
+	dateFormat := flags.String("date-format", "", "Go layout of the birthday column")
+	flags.StringVar(&mapping.Name, "name-column", mapping.Name, "name column header")
+	flags.StringVar(&mapping.Birthday, "birthday-column", mapping.Birthday, "birthday column header")
+	flags.StringVar(&mapping.ExternalID, "external-id-column", mapping.ExternalID, "external id column header")
+	flags.StringVar(&mapping.Email, "email-column", mapping.Email, "email column header")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New(empUsage)
+	}
+
+	path := flags.Arg(0)
+	if *format == "" {
+		*format = importer.FormatFromFilename(path)
+	}
+	var dateFormats []string
+	if *dateFormat != "" {
+		dateFormats = []string{*dateFormat}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	result, err := importer.Read(file, strings.ToLower(*format), mapping, dateFormats)
+	if err != nil {
+		return err
+	}
+	if len(result.Errors) > 0 {
+		for _, rowErr := range result.Errors {
+			fmt.Printf("row %d, %s: %s\n", rowErr.Row, rowErr.Column, rowErr.Error)
+		}
+		return fmt.Errorf("%d invalid row(s), nothing imported", len(result.Errors))
+	}
+
+	created, updated, err := repos.emp.ImportEmployees(ctx, result.Employees, *dryRun)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("created: %d, updated: %d, dry run: %t\n", created, updated, *dryRun)
+	return nil
+}
@@ -3,39 +3,67 @@ package main
 import (
 	"birthday-service/internal/config"
 	"birthday-service/internal/database"
+	database9 "birthday-service/internal/database/board_repo"
+	database7 "birthday-service/internal/database/calendar_repo"
+	database8 "birthday-service/internal/database/collection_repo"
 	database3 "birthday-service/internal/database/emp_repo"
+	database6 "birthday-service/internal/database/outbox_repo"
 	database2 "birthday-service/internal/database/subs_repo"
+	database5 "birthday-service/internal/database/team_repo"
 	database4 "birthday-service/internal/database/user_repo"
+	database10 "birthday-service/internal/database/wishlist_repo"
 	errMsg "birthday-service/internal/err"
-	handlers2 "birthday-service/internal/handlers/emp"
-	handlers3 "birthday-service/internal/handlers/subs"
-	handlers "birthday-service/internal/handlers/user"
-	notification "birthday-service/internal/notification"
-	"birthday-service/jwt"
 	"context"
 	"fmt"
 	"log/slog"
-	"net/http"
 	"os"
-	"time"
-
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
+	// Embedded zone database so user time zones resolve in minimal container images.
+	_ "time/tzdata"
 )
 
-const notificationFrequency = 1
+const usage = `usage: app <command> [arguments]
+
+commands:
+  serve                                  run the HTTP API and notification scheduler (default)
+  migrate [up | down [steps] | status]   manage schema migrations
+  user create --email E --password P [--admin]
+  user reset-password --email E --password P
+  emp import [--dry-run] [--format csv|xlsx] [--date-format L] [--{name,birthday,external-id,email}-column H] file
+  notify run-once [--dry-run] [--date YYYY-MM-DD]
+                                         run the notification jobs once; --dry-run prints the planned
+                                         messages as JSON, --date runs as if today were that date`
+
+type repositories struct {
+	emp         *database3.EmployeeRepository
+	subs        *database2.SubsRepository
+	user        *database4.UserRepository
+	team        *database5.TeamRepository
+	outbox      *database6.OutboxRepository
+	calendar    *database7.CalendarRepository
+	collections *database8.CollectionRepository
+	board       *database9.BoardRepository
+	wishlist    *database10.WishlistRepository
+}
 
 func main() {
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+	if command == "help" || command == "-h" || command == "--help" {
+		fmt.Println(usage)
+		return
+	}
+
 	cfg := config.MustLoad()
 	log := setupLogger()
-	fmt.Println(cfg)
 	log.Debug("debug messages are active")
 	pg, err := connectToPostgres(cfg, log)
 	if err != nil {
 		log.Error("failed to create postgres db", errMsg.Err(err))
 		os.Exit(1)
 	}
-	fmt.Println("connecting to postgres...")
+	log.Info("connecting to postgres", slog.String("db_host", cfg.Database.Host))
 	defer pg.Close()
 	if pg == nil {
 		log.Error("failed to connect to postgres")
@@ -48,79 +76,41 @@ func main() {
 		log.Info("postgres db connected successfully")
 	}
 
-	log.Info("application started")
-
-	router := chi.NewRouter()
-	router.Use(middleware.RequestID)
-	router.Use(middleware.RealIP)
-	router.Use(middleware.Logger)
-	router.Use(middleware.Recoverer)
-	router.Use(middleware.URLFormat)
-
-	cfgSMTP := &config.ConfigSMTP{
-		SMTPHost:     "smtp.yandex.ru",
-		SMTPPort:     587,
-		SMTPUsername: "",
-		SMTPPassword: "",
+	ctx := context.Background()
+	repos := newRepositories(pg, log)
+	switch command {
+	case "serve":
+		err = runServe(ctx, cfg, pg, repos, log)
+	case "migrate":
+		err = runMigrate(ctx, pg, log, args)
+	case "user":
+		err = runUser(ctx, repos, args)
+	case "emp":
+		err = runEmp(ctx, repos, args)
+	case "notify":
+		err = runNotify(ctx, cfg, repos, log, args)
+	default:
+		err = fmt.Errorf("unknown command %q\n%s", command, usage)
 	}
-
-	empRepository := database3.NewEmployeeRepository(pg.Db, log)
-	subsRepository := database2.NewSubsRepository(pg.Db, log)
-	userRepository := database4.NewUserRepository(pg.Db, log)
-	jwtManager := jwt.NewJWTManager(cfg.JWT.Secret, log)
-
-	router.Post("/users/new", handlers.New(log, userRepository))
-	router.Post("/login", handlers.LoginFunc(log, userRepository, jwtManager))
-
-	router.With(func(next http.Handler) http.Handler {
-		return jwt.TokenAuthMiddleware(jwtManager, next)
-	}).Delete("/users/{id}", handlers.DeleteUserHandler(log, userRepository))
-
-	router.With(func(next http.Handler) http.Handler {
-		return jwt.TokenAuthMiddleware(jwtManager, next)
-	}).Patch("/users/{id}", handlers.NewUpdateUserHandler(userRepository, log))
-
-	router.With(func(next http.Handler) http.Handler {
-		return jwt.TokenAuthMiddleware(jwtManager, next)
-	}).Post("/emp", handlers2.New(log, empRepository))
-
-	router.With(func(next http.Handler) http.Handler {
-		return jwt.TokenAuthMiddleware(jwtManager, next)
-	}).Delete("/emp/{id}", handlers2.DeleteEmpHandler(log, empRepository))
-
-	router.With(func(next http.Handler) http.Handler {
-		return jwt.TokenAuthMiddleware(jwtManager, next)
-	}).Get("/employees", handlers2.ListAllEmployees(log, empRepository))
-
-	router.With(func(next http.Handler) http.Handler {
-		return jwt.TokenAuthMiddleware(jwtManager, next)
-	}).Post("/subs", handlers3.New(log, subsRepository))
-
-	router.With(func(next http.Handler) http.Handler {
-		return jwt.TokenAuthMiddleware(jwtManager, next)
-	}).Delete("/subs/{id}", handlers3.DeleteSub(log, subsRepository))
-
-	log.Info("starting server", slog.String("addr", cfg.HTTPServer.Addr))
-	server := &http.Server{
-		Addr:              cfg.HTTPServer.Addr,
-		Handler:           router,
-		ReadHeaderTimeout: cfg.HTTPServer.Timeout,
-		WriteTimeout:      cfg.HTTPServer.Timeout,
-		IdleTimeout:       cfg.HTTPServer.IdleTimeout,
+	if err != nil {
+		log.Error("command failed", slog.String("command", command), errMsg.Err(err))
+		pg.Close()
+		os.Exit(1)
 	}
+}
 
-	go func() {
-		if err := server.ListenAndServe(); err != nil {
-			log.Error("failed to start server", errMsg.Err(err))
-		}
-	}()
-
-	for {
-		ctx := context.Background()
-		notification.SendBirthdayNotifications(ctx, subsRepository, empRepository, cfgSMTP, log)
-		time.Sleep(notificationFrequency * time.Minute)
+func newRepositories(pg *database.Postgres, log *slog.Logger) repositories {
+	return repositories{
+		emp:         database3.NewEmployeeRepository(pg.Db, log),
+		subs:        database2.NewSubsRepository(pg.Db, log),
+		user:        database4.NewUserRepository(pg.Db, log),
+		team:        database5.NewTeamRepository(pg.Db, log),
+		outbox:      database6.NewOutboxRepository(pg.Db, log),
+		calendar:    database7.NewCalendarRepository(pg.Db, log),
+		collections: database8.NewCollectionRepository(pg.Db, log),
+		board:       database9.NewBoardRepository(pg.Db, log),
+		wishlist:    database10.NewWishlistRepository(pg.Db, log),
 	}
-
 }
 
 func setupLogger() *slog.Logger {
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"birthday-service/internal/calendar"
+	"birthday-service/internal/config"
+	"birthday-service/internal/milestone"
+	notification "birthday-service/internal/notification"
+	"birthday-service/internal/outbox"
+	"birthday-service/internal/unsubscribe"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+)
+
+const notifyUsage = "usage: app notify run-once [--dry-run] [--date YYYY-MM-DD]"
+
+func runNotify(ctx context.Context, cfg *config.Config, repos repositories, log *slog.Logger, args []string) error {
+	if len(args) == 0 || args[0] != "run-once" {
+		return errors.New(notifyUsage)
+	}
+
+	flags := flag.NewFlagSet("notify run-once", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "print planned messages instead of sending them")
+	date := flags.String("date", "", "run as if today were this date (YYYY-MM-DD)")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	today := time.Now()
+	if *date != "" {
+		parsed, err := time.Parse("2006-01-02", *date)
+		if err != nil {
+			return fmt.Errorf("invalid date %q: %w", *date, err)
+		}
+		today = notification.OnDate(parsed, today)
+	}
+	workdays, err := newCalendar(cfg, repos)
+	if err != nil {
+		return err
+	}
+
+	if !*dryRun {
+		notifier, err := newNotifier(cfg, repos, workdays, cfg.Notifications.Workers, log)
+		if err != nil {
+			return err
+		}
+		cards, err := newCards(cfg, repos, log)
+		if err != nil {
+			return err
+		}
+		sink := outbox.NewSink(repos.outbox)
+		notifier.SendBirthdayNotifications(ctx, sink, today)
+		cards.SendCards(ctx, sink, today)
+		worker := outbox.NewWorker(repos.outbox, notification.NewSMTPSink(&cfg.SMTP), cfg.Outbox, log)
+		delivered := 0
+		for {
+			processed, err := worker.ProcessBatch(ctx)
+			if err != nil {
+				return err
+			}
+			if processed == 0 {
+				break
+			}
+			delivered += processed
+		}
+		fmt.Printf("processed %d outbox message(s)\n", delivered)
+		return nil
+	}
+
+	notifier, err := newNotifier(cfg, repos, workdays, 1, log)
+	if err != nil {
+		return err
+	}
+	cards, err := newCards(cfg, repos, log)
+	if err != nil {
+		return err
+	}
+	sink := notification.NewDryRunSink()
+	notifier.SendBirthdayNotifications(ctx, sink, today)
+	cards.SendCards(ctx, sink, today)
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sink.Messages())
+}
+
+// newNotifier builds the notification job; a single worker keeps dry-run output in planning order.
+func newNotifier(cfg *config.Config, repos repositories, workdays *calendar.Loader, workers int, log *slog.Logger) (*notification.Notifier, error) {
+	templates, err := notification.LoadTemplates(cfg.Notifications.TemplatesDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notification templates: %w", err)
+	}
+	links, err := unsubscribeLinks(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return notification.NewNotifier(repos.emp, repos.outbox, templates, links, workdays,
+		milestone.Rules(cfg.Notifications.Milestones), workers, log), nil
+}
+
+func newCards(cfg *config.Config, repos repositories, log *slog.Logger) (*notification.Cards, error) {
+	templates, err := notification.LoadTemplates(cfg.Notifications.TemplatesDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notification templates: %w", err)
+	}
+	return notification.NewCards(repos.board, repos.outbox, templates, log), nil
+}
+
+func newCalendar(cfg *config.Config, repos repositories) (*calendar.Loader, error) {
+	workdays, err := calendar.NewLoader(cfg.Calendar, repos.calendar)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load calendar: %w", err)
+	}
+	return workdays, nil
+}
+
+// unsubscribeLinks refuses an empty secret: unsigned tokens for sequential IDs could be forged to
+// unsubscribe anyone.
+func unsubscribeLinks(cfg *config.Config) (*unsubscribe.Links, error) {
+	if cfg.Unsubscribe.Secret == "" {
+		return nil, errors.New("unsubscribe secret is not set, provide it in UNSUBSCRIBE_SECRET")
+	}
+	return unsubscribe.NewLinks(cfg.Unsubscribe.Secret, cfg.Unsubscribe.BaseURL), nil
+}
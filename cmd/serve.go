@@ -0,0 +1,300 @@
+package main
+
+import (
+	"birthday-service/internal/archive"
+	"birthday-service/internal/calendar"
+	"birthday-service/internal/config"
+	"birthday-service/internal/database"
+	errMsg "birthday-service/internal/err"
+	handlers8 "birthday-service/internal/handlers/board"
+	handlers6 "birthday-service/internal/handlers/calendar"
+	handlers7 "birthday-service/internal/handlers/collection"
+	handlers2 "birthday-service/internal/handlers/emp"
+	handlers5 "birthday-service/internal/handlers/notification"
+	handlers3 "birthday-service/internal/handlers/subs"
+	handlers4 "birthday-service/internal/handlers/team"
+	handlers "birthday-service/internal/handlers/user"
+	handlers9 "birthday-service/internal/handlers/wishlist"
+	"birthday-service/internal/leader"
+	"birthday-service/internal/milestone"
+	notification "birthday-service/internal/notification"
+	"birthday-service/internal/outbox"
+	"birthday-service/internal/unsubscribe"
+	"birthday-service/jwt"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const notificationFrequency = 1
+
+// schedulerLockKey is the advisory lock that elects the instance running scheduled jobs.
+const schedulerLockKey int64 = 7_312_150_037
+
+func runServe(ctx context.Context, cfg *config.Config, pg *database.Postgres, repos repositories, log *slog.Logger) error {
+	log.Info("starting service",
+		slog.String("mode", cfg.Instance.Mode),
+		slog.String("address", cfg.HTTPServer.Addr),
+		slog.String("db_host", cfg.Database.Host),
+		slog.String("db_name", cfg.Database.DBName))
+	if !cfg.Instance.RunsAPI() && !cfg.Instance.RunsWorker() {
+		return fmt.Errorf("unknown instance mode %q, expected %s, %s or %s",
+			cfg.Instance.Mode, config.ModeAll, config.ModeAPI, config.ModeWorker)
+	}
+	if cfg.Database.AutoMigrate {
+		if _, err := database.MigrateUp(ctx, pg.Db, log); err != nil {
+			return fmt.Errorf("failed to apply migrations: %w", err)
+		}
+	}
+
+	links, err := unsubscribeLinks(cfg)
+	if err != nil {
+		return err
+	}
+	workdays, err := newCalendar(cfg, repos)
+	if err != nil {
+		return err
+	}
+	notifier, err := newNotifier(cfg, repos, workdays, cfg.Notifications.Workers, log)
+	if err != nil {
+		return err
+	}
+	previewNotifier, err := newNotifier(cfg, repos, workdays, 1, log)
+	if err != nil {
+		return err
+	}
+	templates, err := notification.LoadTemplates(cfg.Notifications.TemplatesDir)
+	if err != nil {
+		return fmt.Errorf("failed to load notification templates: %w", err)
+	}
+	invitations := notification.NewInvitations(templates, outbox.NewSink(repos.outbox), cfg.Unsubscribe.BaseURL)
+	cards := notification.NewCards(repos.board, repos.outbox, templates, log)
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Info("application started", slog.String("mode", cfg.Instance.Mode))
+
+	var server *http.Server
+	if cfg.Instance.RunsAPI() {
+		jwtManager := jwt.NewJWTManager(cfg.JWT.Secret, log)
+		router := newRouter(log, repos, jwtManager, links, previewNotifier, workdays,
+			milestone.Rules(cfg.Notifications.Milestones), invitations)
+
+		log.Info("starting server", slog.String("addr", cfg.HTTPServer.Addr))
+		server = &http.Server{
+			Addr:              cfg.HTTPServer.Addr,
+			Handler:           router,
+			ReadHeaderTimeout: cfg.HTTPServer.Timeout,
+			WriteTimeout:      cfg.HTTPServer.Timeout,
+			IdleTimeout:       cfg.HTTPServer.IdleTimeout,
+		}
+
+		go func() {
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Error("failed to start server", errMsg.Err(err))
+				stop()
+			}
+		}()
+	}
+
+	var wg sync.WaitGroup
+	if cfg.Instance.RunsWorker() {
+		worker := outbox.NewWorker(repos.outbox, notification.NewSMTPSink(&cfg.SMTP), cfg.Outbox, log)
+		elector := leader.NewElector(pg.Db, schedulerLockKey, cfg.Instance.LeaderCheckInterval, log)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			worker.Run(ctx)
+		}()
+		go func() {
+			defer wg.Done()
+			elector.Run(ctx, func(ctx context.Context) {
+				runScheduler(ctx, cfg, repos, notifier, cards, log)
+			})
+		}()
+	}
+
+	<-ctx.Done()
+	log.Info("shutting down")
+	if server != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTPServer.Timeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Error("failed to shut down server", errMsg.Err(err))
+		}
+	}
+	wg.Wait()
+	return nil
+}
+
+// runScheduler runs the periodic jobs; it is only called on the elected leader.
+func runScheduler(ctx context.Context, cfg *config.Config, repos repositories, notifier *notification.Notifier,
+	cards *notification.Cards, log *slog.Logger) {
+	// The purge loop is waited for so losing leadership or shutting down never leaves it running.
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			archive.PurgeEmployees(ctx, repos.emp, cfg.Archive.Retention, log)
+			if !sleep(ctx, cfg.Archive.PurgeInterval) {
+				return
+			}
+		}
+	}()
+
+	sink := outbox.NewSink(repos.outbox)
+	for {
+		notifier.SendBirthdayNotifications(ctx, sink, time.Now())
+		cards.SendCards(ctx, sink, time.Now())
+		if !sleep(ctx, notificationFrequency*time.Minute) {
+			return
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+func newRouter(log *slog.Logger, repos repositories, jwtManager *jwt.JWTManager, links *unsubscribe.Links, previewNotifier *notification.Notifier,
+	workdays *calendar.Loader, milestones milestone.Rules, invitations *notification.Invitations) http.Handler {
+	router := chi.NewRouter()
+	router.Use(middleware.RequestID)
+	router.Use(middleware.RealIP)
+	router.Use(middleware.Logger)
+	router.Use(middleware.Recoverer)
+	router.Use(middleware.URLFormat)
+
+	empRepository := repos.emp
+	subsRepository := repos.subs
+	userRepository := repos.user
+	teamRepository := repos.team
+	outboxRepository := repos.outbox
+	calendarRepository := repos.calendar
+	collectionRepository := repos.collections
+	boardRepository := repos.board
+	wishlistRepository := repos.wishlist
+
+	router.Post("/users/new", handlers.New(log, userRepository))
+	router.Post("/login", handlers.LoginFunc(log, userRepository, jwtManager))
+	router.Get("/unsubscribe", handlers3.UnsubscribePage(log, links))
+	router.Post("/unsubscribe", handlers3.Unsubscribe(log, links, subsRepository, userRepository))
+
+	router.Group(func(r chi.Router) {
+		r.Use(func(next http.Handler) http.Handler {
+			return jwt.TokenAuthMiddleware(jwtManager, next)
+		})
+
+		r.Delete("/users/{id}", handlers.DeleteUserHandler(log, userRepository))
+		r.Patch("/users/{id}", handlers.NewUpdateUserHandler(userRepository, log))
+		r.Get("/me", handlers.Me(log, userRepository, empRepository))
+		r.Patch("/me/employee", handlers.UpdateMyEmployee(log, userRepository, empRepository))
+		r.Put("/me/notifications", handlers.UpdateNotificationSettings(log, userRepository))
+		r.Put("/me/mute", handlers.MuteMe(log, userRepository))
+		r.Delete("/me/mute", handlers.UnmuteMe(log, userRepository))
+		r.Put("/me/quiet-hours", handlers.SetQuietHours(log, userRepository))
+		r.Delete("/me/quiet-hours", handlers.ClearQuietHours(log, userRepository))
+		r.Get("/notifications", handlers5.MyNotifications(log, outboxRepository, userRepository))
+		r.Get("/me/wishlist", handlers9.MyWishlist(log, wishlistRepository, userRepository))
+		r.Post("/me/wishlist", handlers9.AddItem(log, wishlistRepository, userRepository))
+		r.Put("/me/wishlist/{itemId}", handlers9.UpdateItem(log, wishlistRepository, userRepository))
+		r.Delete("/me/wishlist/{itemId}", handlers9.DeleteItem(log, wishlistRepository, userRepository))
+
+		r.Post("/emp", handlers2.New(log, empRepository))
+		r.Get("/emp/{id}/chain", handlers2.ReportingChainHandler(log, empRepository))
+		r.Get("/emp/{id}/reports", handlers2.ReportsHandler(log, empRepository))
+		r.Put("/emp/{id}/privacy", handlers2.SetPrivacyHandler(log, empRepository, userRepository))
+		r.Get("/emp/{id}/events", handlers2.ListEventsHandler(log, empRepository))
+		r.Post("/emp/{id}/events", handlers2.NewEventHandler(log, empRepository, userRepository))
+		r.Delete("/emp/{id}/events/{eventId}", handlers2.DeleteEventHandler(log, empRepository, userRepository))
+		r.Get("/emp/{id}/wishlist", handlers9.EmployeeWishlist(log, wishlistRepository, empRepository,
+			subsRepository, userRepository))
+		r.Put("/emp/{id}/wishlist/{itemId}/reservation", handlers9.ReserveItem(log, wishlistRepository, empRepository,
+			subsRepository, userRepository))
+		r.Delete("/emp/{id}/wishlist/{itemId}/reservation", handlers9.CancelReservation(log, wishlistRepository,
+			empRepository, subsRepository, userRepository))
+		r.Get("/employees", handlers2.ListAllEmployees(log, empRepository))
+		r.Get("/employees/export", handlers2.ExportEmployees(log, empRepository))
+		r.Get("/birthdays/upcoming", handlers2.UpcomingBirthdaysHandler(log, empRepository, milestones))
+		r.Get("/birthdays/{empId}/{year}/messages", handlers8.ListMessages(log, boardRepository, empRepository,
+			subsRepository, userRepository))
+		r.Post("/birthdays/{empId}/{year}/messages", handlers8.WriteMessage(log, boardRepository, empRepository,
+			subsRepository, userRepository))
+		r.Delete("/birthdays/{empId}/{year}/messages", handlers8.DeleteMessage(log, boardRepository, empRepository,
+			subsRepository, userRepository))
+
+		r.Get("/subs", handlers3.ListMySubs(log, subsRepository, userRepository))
+		r.Post("/subs", handlers3.New(log, subsRepository, userRepository))
+		r.Post("/subs/bulk", handlers3.BulkSubscribe(log, subsRepository, userRepository))
+		r.Delete("/subs/bulk", handlers3.BulkUnsubscribe(log, subsRepository, userRepository))
+		r.Put("/subs/{id}/mute", handlers3.MuteSub(log, subsRepository, userRepository))
+		r.Delete("/subs/{id}/mute", handlers3.UnmuteSub(log, subsRepository, userRepository))
+		r.Patch("/subs/{id}", handlers3.UpdateSubOptions(log, subsRepository, userRepository))
+		r.Delete("/subs/{id}", handlers3.DeleteSub(log, subsRepository, userRepository))
+
+		r.Get("/departments", handlers4.ListDepartments(log, teamRepository))
+		r.Get("/teams", handlers4.ListTeams(log, teamRepository))
+		r.Get("/teams/{id}/members", handlers4.ListMembers(log, teamRepository))
+
+		r.Get("/calendar/holidays", handlers6.ListHolidays(log, workdays))
+
+		r.Post("/collections", handlers7.OpenCollection(log, collectionRepository, empRepository, subsRepository,
+			userRepository, invitations))
+		r.Get("/collections", handlers7.ListCollections(log, collectionRepository, userRepository))
+		r.Get("/collections/{id}", handlers7.GetCollection(log, collectionRepository, userRepository))
+		r.Put("/collections/{id}/pledge", handlers7.SavePledge(log, collectionRepository, subsRepository, userRepository))
+		r.Delete("/collections/{id}/pledge", handlers7.DeletePledge(log, collectionRepository, userRepository))
+		r.Put("/collections/{id}/pledges/{userId}/paid", handlers7.SetPledgePaid(log, collectionRepository, userRepository))
+		r.Post("/collections/{id}/close", handlers7.CloseCollection(log, collectionRepository, userRepository))
+	})
+
+	router.Group(func(r chi.Router) {
+		r.Use(func(next http.Handler) http.Handler {
+			return jwt.TokenAuthAndRoleMiddleware(jwtManager, next)
+		})
+
+		r.Put("/users/{id}/employee", handlers.LinkEmployeeHandler(log, userRepository))
+		r.Post("/users/link-by-email", handlers.LinkByEmailHandler(log, userRepository))
+		r.Get("/users/{id}/subs/export", handlers3.ExportUserSubs(log, subsRepository))
+
+		r.Post("/emp/import", handlers2.ImportEmployees(log, empRepository))
+		r.Delete("/emp/{id}", handlers2.DeleteEmpHandler(log, empRepository))
+		r.Post("/emp/{id}/restore", handlers2.RestoreEmpHandler(log, empRepository))
+		r.Put("/emp/{id}/manager", handlers2.SetManagerHandler(log, empRepository))
+
+		r.Post("/departments", handlers4.NewDepartment(log, teamRepository))
+		r.Delete("/departments/{id}", handlers4.DeleteDepartment(log, teamRepository))
+		r.Post("/teams", handlers4.NewTeam(log, teamRepository))
+		r.Delete("/teams/{id}", handlers4.DeleteTeam(log, teamRepository))
+		r.Post("/teams/{id}/members", handlers4.AddMember(log, teamRepository))
+		r.Delete("/teams/{id}/members/{empId}", handlers4.RemoveMember(log, teamRepository))
+
+		r.Get("/notifications/preview", handlers5.Preview(log, previewNotifier))
+		r.Get("/notifications/search", handlers5.SearchNotifications(log, outboxRepository))
+		r.Get("/emp/{id}/subscribers", handlers3.ListEmployeeSubscribers(log, subsRepository))
+		r.Get("/outbox", handlers5.ListOutbox(log, outboxRepository))
+		r.Post("/outbox/{id}/resend", handlers5.ResendOutboxMessage(log, outboxRepository))
+		r.Put("/calendar/holidays/{date}", handlers6.SaveHoliday(log, calendarRepository))
+		r.Delete("/calendar/holidays/{date}", handlers6.DeleteHoliday(log, calendarRepository))
+	})
+
+	return router
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"birthday-service/internal/auth"
+	"birthday-service/internal/entities"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+)
+
+const userUsage = "usage: app user [create --email E --password P [--admin] | reset-password --email E --password P]"
+
+func runUser(ctx context.Context, repos repositories, args []string) error {
+	if len(args) == 0 {
+		return errors.New(userUsage)
+	}
+
+	flags := flag.NewFlagSet("user "+args[0], flag.ContinueOnError)
+	email := flags.String("email", "", "user email")
+	password := flags.String("password", "", "user password")
+	admin := flags.Bool("admin", false, "grant the admin role (create only)")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *email == "" || *password == "" {
+		return errors.New(userUsage)
+	}
+	hash, err := auth.HashPassword(*password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	switch args[0] {
+	case "create":
+		user := entities.User{Email: *email, Password: hash, IsAdmin: *admin}
+		if err := repos.user.CreateUser(ctx, &user); err != nil {
+			return err
+		}
+		fmt.Printf("created user %d (%s), admin: %t\n", user.ID, user.Email, user.IsAdmin)
+	case "reset-password":
+		user, err := repos.user.FindUserByEmail(ctx, *email)
+		if err != nil {
+			return err
+		}
+		user.Password = hash
+		if err := repos.user.UpdateUser(ctx, &user); err != nil {
+			return err
+		}
+		fmt.Printf("password reset for user %d (%s)\n", user.ID, user.Email)
+	default:
+		return errors.New(userUsage)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package archive
+
+import (
+	errMsg "birthday-service/internal/err"
+	"context"
+	"log/slog"
+	"time"
+)
+
+type Purger interface {
+	PurgeArchived(ctx context.Context, archivedBefore time.Time) (int, error)
+}
+
+func PurgeEmployees(ctx context.Context, empRepository Purger, retention time.Duration, log *slog.Logger) {
+	purged, err := empRepository.PurgeArchived(ctx, time.Now().Add(-retention))
+	if err != nil {
+		log.Error("failed to purge archived employees", errMsg.Err(err))
+		return
+	}
+	if purged > 0 {
+		log.Info("archived employees purged", slog.Int("count", purged))
+	}
+}
@@ -0,0 +1,163 @@
+package calendar
+
+import (
+	"birthday-service/internal/config"
+	"birthday-service/internal/entities"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// MaxShift bounds how far back a reminder may move, covering long holiday runs such as New Year.
+const MaxShift = 31
+
+// Calendar tells business days from days off.
+type Calendar struct {
+	weekends map[time.Weekday]bool
+	days     map[string]entities.Holiday
+}
+
+func New(weekends []time.Weekday, holidays []entities.Holiday) *Calendar {
+	c := &Calendar{weekends: make(map[time.Weekday]bool, len(weekends)), days: make(map[string]entities.Holiday, len(holidays))}
+	for _, day := range weekends {
+		c.weekends[day] = true
+	}
+	for _, holiday := range holidays {
+		c.days[holiday.Date.Format(dateLayout)] = holiday
+	}
+	return c
+}
+
+func (c *Calendar) IsBusinessDay(day time.Time) bool {
+	if holiday, ok := c.days[day.Format(dateLayout)]; ok {
+		return holiday.Working
+	}
+	return !c.weekends[day.Weekday()]
+}
+
+// PreviousBusinessDay returns day itself when it is a business day, otherwise the closest
+// business day before it.
+func (c *Calendar) PreviousBusinessDay(day time.Time) time.Time {
+	for i := 0; i < MaxShift && !c.IsBusinessDay(day); i++ {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// Holidays returns the overrides between from and to inclusive, ordered by date.
+func (c *Calendar) Holidays(from, to time.Time) []entities.Holiday {
+	var holidays []entities.Holiday
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if holiday, ok := c.days[day.Format(dateLayout)]; ok {
+			holidays = append(holidays, holiday)
+		}
+	}
+	return holidays
+}
+
+// Store keeps the days managed through the API.
+type Store interface {
+	ListHolidays(ctx context.Context, from, to time.Time) ([]entities.Holiday, error)
+}
+
+// Loader builds calendars from the configured weekends, the holidays file and the store.
+type Loader struct {
+	weekends []time.Weekday
+	file     []entities.Holiday
+	store    Store
+}
+
+func NewLoader(cfg config.CalendarCfg, store Store) (*Loader, error) {
+	weekends, err := ParseWeekdays(cfg.Weekends)
+	if err != nil {
+		return nil, err
+	}
+	loader := &Loader{weekends: weekends, store: store}
+	if cfg.HolidaysFile != "" {
+		loader.file, err = LoadFile(cfg.HolidaysFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return loader, nil
+}
+
+// Load returns the calendar for the days between from and to; reminders may shift up to
+// MaxShift days, so callers need not widen the range themselves.
+func (l *Loader) Load(ctx context.Context, from, to time.Time) (*Calendar, error) {
+	stored, err := l.store.ListHolidays(ctx, from.AddDate(0, 0, -MaxShift), to)
+	if err != nil {
+		return nil, err
+	}
+	return New(l.weekends, append(append([]entities.Holiday{}, l.file...), stored...)), nil
+}
+
+func ParseWeekdays(names []string) ([]time.Weekday, error) {
+	weekdays := make([]time.Weekday, 0, len(names))
+	for _, name := range names {
+		day, ok := weekdayNames[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", name)
+		}
+		weekdays = append(weekdays, day)
+	}
+	return weekdays, nil
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+// LoadFile reads a holidays CSV with date,name[,working] rows; a header row is skipped.
+func LoadFile(path string) ([]entities.Holiday, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Parse(file)
+}
+
+func Parse(r io.Reader) ([]entities.Holiday, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var holidays []entities.Holiday
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return holidays, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		date, err := time.Parse(dateLayout, strings.TrimSpace(record[0]))
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: invalid date %q", line, record[0])
+		}
+		holiday := entities.Holiday{Date: date}
+		if len(record) > 1 {
+			holiday.Name = strings.TrimSpace(record[1])
+		}
+		if len(record) > 2 && strings.TrimSpace(record[2]) != "" {
+			holiday.Working, err = strconv.ParseBool(strings.TrimSpace(record[2]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid working flag %q", line, record[2])
+			}
+		}
+		holidays = append(holidays, holiday)
+	}
+}
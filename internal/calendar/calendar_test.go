@@ -0,0 +1,97 @@
+package calendar
+
+import (
+	"birthday-service/internal/entities"
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPreviousBusinessDay(t *testing.T) {
+	holidays := []entities.Holiday{
+		{Date: date(time.May, 1), Name: "Labour Day"},
+		{Date: date(time.May, 9), Name: "Victory Day"},
+		{Date: date(time.May, 11), Name: "Victory Day (moved)"},
+		// A Saturday made a working day to bridge a holiday.
+		{Date: date(time.February, 28), Name: "Working Saturday", Working: true},
+	}
+	for day := 1; day <= 31; day++ {
+		holidays = append(holidays, entities.Holiday{Date: time.Date(2027, time.January, day, 0, 0, 0, 0, time.UTC)})
+	}
+	cal := New([]time.Weekday{time.Saturday, time.Sunday}, holidays)
+
+	tests := []struct {
+		name string
+		day  time.Time
+		want time.Time
+	}{
+		{name: "business day stays", day: date(time.May, 6), want: date(time.May, 6)},
+		{name: "saturday moves to friday", day: date(time.May, 16), want: date(time.May, 15)},
+		{name: "sunday moves to friday", day: date(time.May, 17), want: date(time.May, 15)},
+		{name: "holiday moves back", day: date(time.May, 1), want: date(time.April, 30)},
+		{name: "holiday run and weekend", day: date(time.May, 11), want: date(time.May, 8)},
+		{name: "working saturday stays", day: date(time.February, 28), want: date(time.February, 28)},
+		{name: "sunday after working saturday", day: date(time.March, 1), want: date(time.February, 28)},
+		{name: "shift is bounded", day: time.Date(2027, time.January, 31, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2027, time.January, 31, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -MaxShift)},
+	}
+	for _, tt := range tests {
+		if got := cal.PreviousBusinessDay(tt.day); !got.Equal(tt.want) {
+			t.Errorf("%s: PreviousBusinessDay(%s) = %s, want %s", tt.name,
+				tt.day.Format(dateLayout), got.Format(dateLayout), tt.want.Format(dateLayout))
+		}
+	}
+}
+
+func TestParseWeekdays(t *testing.T) {
+	tests := []struct {
+		names   []string
+		want    []time.Weekday
+		wantErr bool
+	}{
+		{names: []string{"Saturday", " sunday "}, want: []time.Weekday{time.Saturday, time.Sunday}},
+		{names: nil, want: []time.Weekday{}},
+		{names: []string{"funday"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseWeekdays(tt.names)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ParseWeekdays(%v) error = %v", tt.names, err)
+		}
+		if !tt.wantErr && len(got) != len(tt.want) {
+			t.Fatalf("ParseWeekdays(%v) = %v, want %v", tt.names, got, tt.want)
+		}
+		for i := range tt.want {
+			if got[i] != tt.want[i] {
+				t.Fatalf("ParseWeekdays(%v) = %v, want %v", tt.names, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{name: "with header", input: "date,name,working\n2026-01-01,New Year\n2026-02-28,Working Saturday,true\n", want: 2},
+		{name: "without header", input: "2026-01-01,New Year\n", want: 1},
+		{name: "invalid date", input: "2026-01-01,New Year\n01.01.2026,New Year\n", wantErr: true},
+		{name: "invalid working flag", input: "2026-02-28,Working Saturday,maybe\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		holidays, err := Parse(strings.NewReader(tt.input))
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("%s: Parse error = %v", tt.name, err)
+		}
+		if len(holidays) != tt.want {
+			t.Fatalf("%s: Parse returned %d holidays, want %d", tt.name, len(holidays), tt.want)
+		}
+	}
+}
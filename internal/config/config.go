@@ -2,16 +2,24 @@ package config
 
 import (
 	"log"
+	"os"
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 type Config struct {
-	HTTPServer       ServerCfg      `yaml:"http_server"`
-	Database         DatabaseConfig `yaml:"database"`
-	JWT              JWTCfg         `yaml:"auth"`
-	DefaultAdminPass string         `yaml:"default_admin_pass"`
+	HTTPServer       ServerCfg       `yaml:"http_server"`
+	Database         DatabaseConfig  `yaml:"database"`
+	JWT              JWTCfg          `yaml:"jwt"`
+	DefaultAdminPass string          `yaml:"default_admin_pass"`
+	Archive          ArchiveCfg      `yaml:"archive"`
+	SMTP             ConfigSMTP      `yaml:"smtp"`
+	Outbox           OutboxCfg       `yaml:"outbox"`
+	Instance         InstanceCfg     `yaml:"instance"`
+	Notifications    NotificationCfg `yaml:"notifications"`
+	Unsubscribe      UnsubscribeCfg  `yaml:"unsubscribe"`
+	Calendar         CalendarCfg     `yaml:"calendar"`
 }
 
 type DatabaseConfig struct {
@@ -20,6 +28,8 @@ type DatabaseConfig struct {
 	User     string `yaml:"user"`
 	Password string `yaml:"password"`
 	DBName   string `yaml:"dbname"`
+	// AutoMigrate applies pending migrations when the server starts.
+	AutoMigrate bool `yaml:"auto_migrate" env-default:"true"`
 }
 
 type ServerCfg struct {
@@ -28,21 +38,106 @@ type ServerCfg struct {
 	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"120s"`
 }
 
+type ArchiveCfg struct {
+	Retention     time.Duration `yaml:"retention" env-default:"720h"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env-default:"1h"`
+}
+
+const (
+	ModeAll    = "all"
+	ModeAPI    = "api"
+	ModeWorker = "worker"
+)
+
+type InstanceCfg struct {
+	// Mode selects what the instance runs: the HTTP API, the notification workers, or both.
+	Mode string `yaml:"mode" env:"APP_MODE" env-default:"all"`
+	// LeaderCheckInterval is how often a standby instance retries leadership and the leader checks its lock.
+	LeaderCheckInterval time.Duration `yaml:"leader_check_interval" env-default:"15s"`
+}
+
+func (c InstanceCfg) RunsAPI() bool {
+	return c.Mode == ModeAll || c.Mode == ModeAPI
+}
+
+func (c InstanceCfg) RunsWorker() bool {
+	return c.Mode == ModeAll || c.Mode == ModeWorker
+}
+
+type NotificationCfg struct {
+	// Workers bounds how many messages a notification run hands to the outbox concurrently.
+	Workers int `yaml:"workers" env-default:"8"`
+	// TemplatesDir may hold birthday.tmpl and digest.tmpl overriding the built-in templates.
+	TemplatesDir string          `yaml:"templates_dir"`
+	Milestones   []MilestoneRule `yaml:"milestones"`
+}
+
+// MilestoneRule marks round birthdays that are announced earlier and to extra recipients such as HR.
+type MilestoneRule struct {
+	Ages []int `yaml:"ages"`
+	// Every matches all ages divisible by it, e.g. 10 for 30, 40, 50...
+	Every      int      `yaml:"every"`
+	DaysBefore int      `yaml:"days_before"`
+	Recipients []string `yaml:"recipients"`
+}
+
+func (r MilestoneRule) Matches(age int) bool {
+	if age <= 0 {
+		return false
+	}
+	if r.Every > 0 && age%r.Every == 0 {
+		return true
+	}
+	for _, milestone := range r.Ages {
+		if milestone == age {
+			return true
+		}
+	}
+	return false
+}
+
+type UnsubscribeCfg struct {
+	// BaseURL is the public address of the API used in unsubscribe links.
+	BaseURL string `yaml:"base_url" env:"PUBLIC_BASE_URL" env-default:"http://localhost:8080"`
+	Secret  string `yaml:"secret" env:"UNSUBSCRIBE_SECRET"`
+}
+
+type CalendarCfg struct {
+	Weekends []string `yaml:"weekends" env-default:"Saturday,Sunday"`
+	// HolidaysFile is a CSV of date,name[,working] rows; days stored via the API take precedence.
+	HolidaysFile string `yaml:"holidays_file"`
+}
+
+type OutboxCfg struct {
+	Workers      int           `yaml:"workers" env-default:"2"`
+	BatchSize    int           `yaml:"batch_size" env-default:"10"`
+	MaxAttempts  int           `yaml:"max_attempts" env-default:"8"`
+	BaseBackoff  time.Duration `yaml:"base_backoff" env-default:"30s"`
+	MaxBackoff   time.Duration `yaml:"max_backoff" env-default:"1h"`
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"5s"`
+	// Lease is how long a claimed message stays locked before another worker may retry it.
+	Lease time.Duration `yaml:"lease" env-default:"5m"`
+}
+
 type JWTCfg struct {
 	Secret string `yaml:"secret"`
 }
 
 type ConfigSMTP struct {
-	SMTPHost     string
-	SMTPPort     int
-	SMTPUsername string
-	SMTPPassword string
+	SMTPHost     string `yaml:"host" env-default:"smtp.yandex.ru"`
+	SMTPPort     int    `yaml:"port" env-default:"587"`
+	SMTPUsername string `yaml:"username" env:"SMTP_USERNAME"`
+	SMTPPassword string `yaml:"password" env:"SMTP_PASSWORD"`
 }
 
 func MustLoad() *Config {
 	var cfg Config
 
-	err := cleanenv.ReadConfig("../config/config.yaml", &cfg)
+	path := os.Getenv("CONFIG_PATH")
+	if path == "" {
+		path = "../config/config.yaml"
+	}
+	err := cleanenv.ReadConfig(path, &cfg)
 	if err != nil {
 		log.Fatalf("cannot read config: %s", err)
 	}
@@ -0,0 +1,121 @@
+package database
+
+import (
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type BoardRepository struct {
+	db  *pgxpool.Pool
+	log *slog.Logger
+}
+
+func NewBoardRepository(db *pgxpool.Pool, log *slog.Logger) *BoardRepository {
+	return &BoardRepository{db, log}
+}
+
+// SaveMessage writes the author's message on the card, replacing the one they left before.
+func (b *BoardRepository) SaveMessage(ctx context.Context, message *entities.BoardMessage) error {
+	err := b.db.QueryRow(ctx, `INSERT INTO BoardMessages (emp_id, year, author_id, body)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (emp_id, year, author_id) DO UPDATE SET body = EXCLUDED.body, updated_at = NOW()
+		RETURNING id, created_at, updated_at`,
+		message.EmployeeID, message.Year, message.AuthorID, message.Body).Scan(&message.ID, &message.CreatedAt, &message.UpdatedAt)
+	if err != nil {
+		b.log.Error("failed to save board message", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (b *BoardRepository) GetMessages(ctx context.Context, empID, year int) ([]entities.BoardMessage, error) {
+	rows, err := b.db.Query(ctx, `SELECT m.id, m.emp_id, m.year, m.author_id, u.email, m.body, m.created_at, m.updated_at
+		FROM BoardMessages m JOIN Users u ON u.id = m.author_id
+		WHERE m.emp_id = $1 AND m.year = $2
+		ORDER BY m.created_at, m.id`, empID, year)
+	if err != nil {
+		b.log.Error("failed to get board messages", errMsg.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []entities.BoardMessage
+	for rows.Next() {
+		var message entities.BoardMessage
+		if err := rows.Scan(&message.ID, &message.EmployeeID, &message.Year, &message.AuthorID, &message.AuthorEmail,
+			&message.Body, &message.CreatedAt, &message.UpdatedAt); err != nil {
+			b.log.Error("failed to scan board message", errMsg.Err(err))
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, rows.Err()
+}
+
+func (b *BoardRepository) DeleteMessage(ctx context.Context, empID, year, authorID int) (bool, error) {
+	tag, err := b.db.Exec(ctx, `DELETE FROM BoardMessages WHERE emp_id = $1 AND year = $2 AND author_id = $3`,
+		empID, year, authorID)
+	if err != nil {
+		b.log.Error("failed to delete board message", errMsg.Err(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetDueCards returns the cards of birthdays between from and to that have at least one message.
+// Feb 29 birthdays fall on Feb 28 in other years, as in GetUpcomingBirthdays.
+func (b *BoardRepository) GetDueCards(ctx context.Context, from, to time.Time) ([]entities.Card, error) {
+	rows, err := b.db.Query(ctx, `SELECT e.id, e.name, e.birthday, COALESCE(e.email, ''), m.year, o.occurrence,
+			COALESCE(u.id, 0), COALESCE(u.email, ''), COALESCE(u.unsubscribed_all, FALSE), COALESCE(u.timezone, ''),
+			u.delivery_hour,
+			m.id, m.author_id, a.email, m.body, m.created_at, m.updated_at
+		FROM BoardMessages m
+		JOIN Employees e ON e.id = m.emp_id
+		JOIN Users a ON a.id = m.author_id
+		CROSS JOIN LATERAL (
+			SELECT (e.birthday + make_interval(years => m.year - EXTRACT(YEAR FROM e.birthday)::int))::date AS occurrence
+		) o
+		LEFT JOIN LATERAL (
+			SELECT id, email, unsubscribed_all, timezone, delivery_hour FROM Users
+			WHERE employee_id = e.id ORDER BY id LIMIT 1
+		) u ON TRUE
+		WHERE e.archived_at IS NULL AND o.occurrence BETWEEN $1 AND $2
+		ORDER BY e.id, m.year, m.created_at, m.id`, from, to)
+	if err != nil {
+		b.log.Error("failed to get due cards", errMsg.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cards []entities.Card
+	for rows.Next() {
+		var (
+			card    entities.Card
+			message entities.BoardMessage
+		)
+		if err := rows.Scan(&card.Employee.ID, &card.Employee.Name, &card.Employee.Birthday, &card.Employee.Email,
+			&card.Year, &card.Occurrence, &card.Recipient.ID, &card.Recipient.Email, &card.Recipient.UnsubscribedAll,
+			&card.Recipient.Timezone, &card.Recipient.DeliveryHour,
+			&message.ID, &message.AuthorID, &message.AuthorEmail, &message.Body, &message.CreatedAt, &message.UpdatedAt); err != nil {
+			b.log.Error("failed to scan card", errMsg.Err(err))
+			return nil, err
+		}
+		message.EmployeeID, message.Year = card.Employee.ID, card.Year
+		if n := len(cards); n > 0 && cards[n-1].Employee.ID == card.Employee.ID && cards[n-1].Year == card.Year {
+			cards[n-1].Messages = append(cards[n-1].Messages, message)
+			continue
+		}
+		card.Recipient.EmployeeID = card.Employee.ID
+		if card.Recipient.Email == "" {
+			card.Recipient.Email = card.Employee.Email
+		}
+		card.Messages = []entities.BoardMessage{message}
+		cards = append(cards, card)
+	}
+	return cards, rows.Err()
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CalendarRepository struct {
+	db  *pgxpool.Pool
+	log *slog.Logger
+}
+
+func NewCalendarRepository(db *pgxpool.Pool, log *slog.Logger) *CalendarRepository {
+	return &CalendarRepository{db, log}
+}
+
+func (c *CalendarRepository) ListHolidays(ctx context.Context, from, to time.Time) ([]entities.Holiday, error) {
+	rows, err := c.db.Query(ctx, `SELECT day, name, working FROM Holidays
+		WHERE day BETWEEN $1::date AND $2::date
+		ORDER BY day`, from, to)
+	if err != nil {
+		c.log.Error("failed to get holidays", errMsg.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holidays []entities.Holiday
+	for rows.Next() {
+		var holiday entities.Holiday
+		if err := rows.Scan(&holiday.Date, &holiday.Name, &holiday.Working); err != nil {
+			c.log.Error("failed to scan holiday", errMsg.Err(err))
+			return nil, err
+		}
+		holidays = append(holidays, holiday)
+	}
+	return holidays, rows.Err()
+}
+
+func (c *CalendarRepository) SaveHoliday(ctx context.Context, holiday entities.Holiday) error {
+	_, err := c.db.Exec(ctx, `INSERT INTO Holidays (day, name, working) VALUES ($1::date, $2, $3)
+		ON CONFLICT (day) DO UPDATE SET name = EXCLUDED.name, working = EXCLUDED.working`,
+		holiday.Date, holiday.Name, holiday.Working)
+	if err != nil {
+		c.log.Error("failed to save holiday", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (c *CalendarRepository) DeleteHoliday(ctx context.Context, day time.Time) (bool, error) {
+	tag, err := c.db.Exec(ctx, `DELETE FROM Holidays WHERE day = $1::date`, day)
+	if err != nil {
+		c.log.Error("failed to delete holiday", errMsg.Err(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,163 @@
+package database
+
+import (
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CollectionRepository struct {
+	db  *pgxpool.Pool
+	log *slog.Logger
+}
+
+func NewCollectionRepository(db *pgxpool.Pool, log *slog.Logger) *CollectionRepository {
+	return &CollectionRepository{db, log}
+}
+
+const collectionQuery = `SELECT c.id, c.emp_id, e.name, c.occurrence, c.organiser_id, c.title, c.target,
+		COALESCE(SUM(p.amount), 0), COALESCE(SUM(p.amount) FILTER (WHERE p.paid_at IS NOT NULL), 0), COUNT(p.user_id),
+		c.closed_at, c.created_at
+	FROM Collections c
+	JOIN Employees e ON e.id = c.emp_id
+	LEFT JOIN Pledges p ON p.collection_id = c.id`
+
+// CreateCollection opens a collection; it returns false when one is already open for that
+// birthday, filling in the existing collection's ID.
+func (c *CollectionRepository) CreateCollection(ctx context.Context, collection *entities.Collection) (bool, error) {
+	err := c.db.QueryRow(ctx, `INSERT INTO Collections (emp_id, occurrence, organiser_id, title, target)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (emp_id, occurrence) DO NOTHING
+		RETURNING id`,
+		collection.EmployeeID, collection.Occurrence, collection.OrganiserID, collection.Title, collection.Target).Scan(&collection.ID)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		c.log.Error("failed to create collection", errMsg.Err(err))
+		return false, err
+	}
+	err = c.db.QueryRow(ctx, `SELECT id FROM Collections WHERE emp_id = $1 AND occurrence = $2`,
+		collection.EmployeeID, collection.Occurrence).Scan(&collection.ID)
+	if err != nil {
+		c.log.Error("failed to find existing collection", errMsg.Err(err))
+		return false, err
+	}
+	return false, nil
+}
+
+func (c *CollectionRepository) GetCollection(ctx context.Context, id int) (entities.Collection, bool, error) {
+	rows, err := c.db.Query(ctx, collectionQuery+` WHERE c.id = $1 GROUP BY c.id, e.name`, id)
+	if err != nil {
+		c.log.Error("failed to get collection", errMsg.Err(err))
+		return entities.Collection{}, false, err
+	}
+	collections, err := c.scanCollections(rows)
+	if err != nil || len(collections) == 0 {
+		return entities.Collection{}, false, err
+	}
+	return collections[0], true, nil
+}
+
+// ListCollections returns open collections, leaving out those for excludeEmployeeID so the
+// birthday person never sees their own.
+func (c *CollectionRepository) ListCollections(ctx context.Context, excludeEmployeeID int) ([]entities.Collection, error) {
+	rows, err := c.db.Query(ctx, collectionQuery+`
+		WHERE c.closed_at IS NULL AND c.emp_id <> $1
+		GROUP BY c.id, e.name
+		ORDER BY c.occurrence, c.id`, excludeEmployeeID)
+	if err != nil {
+		c.log.Error("failed to list collections", errMsg.Err(err))
+		return nil, err
+	}
+	return c.scanCollections(rows)
+}
+
+func (c *CollectionRepository) scanCollections(rows pgx.Rows) ([]entities.Collection, error) {
+	defer rows.Close()
+
+	var collections []entities.Collection
+	for rows.Next() {
+		var collection entities.Collection
+		if err := rows.Scan(&collection.ID, &collection.EmployeeID, &collection.EmployeeName, &collection.Occurrence,
+			&collection.OrganiserID, &collection.Title, &collection.Target, &collection.Pledged, &collection.Paid,
+			&collection.Pledgers, &collection.ClosedAt, &collection.CreatedAt); err != nil {
+			c.log.Error("failed to scan collection", errMsg.Err(err))
+			return nil, err
+		}
+		collections = append(collections, collection)
+	}
+	return collections, rows.Err()
+}
+
+func (c *CollectionRepository) CloseCollection(ctx context.Context, id int) error {
+	_, err := c.db.Exec(ctx, `UPDATE Collections SET closed_at = COALESCE(closed_at, NOW()) WHERE id = $1`, id)
+	if err != nil {
+		c.log.Error("failed to close collection", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+// SavePledge creates or changes the user's pledge; paid marks or unmarks the payment.
+func (c *CollectionRepository) SavePledge(ctx context.Context, collectionID, userID, amount int, paid bool) error {
+	_, err := c.db.Exec(ctx, `INSERT INTO Pledges (collection_id, user_id, amount, paid_at)
+		VALUES ($1, $2, $3, CASE WHEN $4 THEN NOW() END)
+		ON CONFLICT (collection_id, user_id) DO UPDATE SET amount = EXCLUDED.amount,
+			paid_at = CASE WHEN $4 THEN COALESCE(Pledges.paid_at, NOW()) END, updated_at = NOW()`,
+		collectionID, userID, amount, paid)
+	if err != nil {
+		c.log.Error("failed to save pledge", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+// SetPledgePaid lets the organiser confirm or reset a payment.
+func (c *CollectionRepository) SetPledgePaid(ctx context.Context, collectionID, userID int, paid bool) (bool, error) {
+	tag, err := c.db.Exec(ctx, `UPDATE Pledges
+		SET paid_at = CASE WHEN $3 THEN COALESCE(paid_at, NOW()) END, updated_at = NOW()
+		WHERE collection_id = $1 AND user_id = $2`, collectionID, userID, paid)
+	if err != nil {
+		c.log.Error("failed to update pledge payment", errMsg.Err(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (c *CollectionRepository) DeletePledge(ctx context.Context, collectionID, userID int) (bool, error) {
+	tag, err := c.db.Exec(ctx, `DELETE FROM Pledges WHERE collection_id = $1 AND user_id = $2`, collectionID, userID)
+	if err != nil {
+		c.log.Error("failed to delete pledge", errMsg.Err(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (c *CollectionRepository) GetPledges(ctx context.Context, collectionID int) ([]entities.Pledge, error) {
+	rows, err := c.db.Query(ctx, `SELECT p.user_id, u.email, p.amount, p.paid_at, p.updated_at
+		FROM Pledges p JOIN Users u ON u.id = p.user_id
+		WHERE p.collection_id = $1
+		ORDER BY p.updated_at`, collectionID)
+	if err != nil {
+		c.log.Error("failed to get pledges", errMsg.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pledges []entities.Pledge
+	for rows.Next() {
+		var pledge entities.Pledge
+		if err := rows.Scan(&pledge.UserID, &pledge.Email, &pledge.Amount, &pledge.PaidAt, &pledge.UpdatedAt); err != nil {
+			c.log.Error("failed to scan pledge", errMsg.Err(err))
+			return nil, err
+		}
+		pledges = append(pledges, pledge)
+	}
+	return pledges, rows.Err()
+}
@@ -4,12 +4,18 @@ import (
 	"birthday-service/internal/entities"
 	errMsg "birthday-service/internal/err"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const employeeColumns = `id, name, birthday, COALESCE(external_id, ''), COALESCE(manager_id, 0), COALESCE(email, ''),
+	birthday_visibility, notifications_opt_out, archived_at`
+
 type EmployeeRepository struct {
 	db  *pgxpool.Pool
 	log *slog.Logger
@@ -19,8 +25,15 @@ func NewEmployeeRepository(db *pgxpool.Pool, log *slog.Logger) *EmployeeReposito
 	return &EmployeeRepository{db, log}
 }
 
+func scanEmployee(row pgx.Row, employee *entities.Employee) error {
+	return row.Scan(&employee.ID, &employee.Name, &employee.Birthday, &employee.ExternalID, &employee.ManagerID, &employee.Email,
+		&employee.Visibility, &employee.OptOut, &employee.ArchivedAt)
+}
+
 func (e *EmployeeRepository) CreateEmployee(ctx context.Context, employee *entities.Employee) error {
-	err := e.db.QueryRow(ctx, `INSERT INTO Employees (name, birthday) VALUES ($1, $2) RETURNING id`, employee.Name, employee.Birthday).Scan(&employee.ID)
+	err := e.db.QueryRow(ctx, `INSERT INTO Employees (name, birthday, external_id, manager_id, email)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, 0), NULLIF($5, '')) RETURNING id`,
+		employee.Name, employee.Birthday, employee.ExternalID, employee.ManagerID, employee.Email).Scan(&employee.ID)
 	if err != nil {
 		e.log.Error("failed to create Employee", errMsg.Err(err))
 		return err
@@ -29,7 +42,7 @@ func (e *EmployeeRepository) CreateEmployee(ctx context.Context, employee *entit
 }
 
 func (e *EmployeeRepository) FindEmployeeByName(ctx context.Context, name string) (entities.Employee, error) {
-	query, err := e.db.Query(ctx, `SELECT * FROM Employees WHERE name = $1`, name)
+	query, err := e.db.Query(ctx, `SELECT `+employeeColumns+` FROM Employees WHERE name = $1`, name)
 	if err != nil {
 		e.log.Error("Error querying users", errMsg.Err(err))
 		return entities.Employee{}, err
@@ -40,7 +53,7 @@ func (e *EmployeeRepository) FindEmployeeByName(ctx context.Context, name string
 		e.log.Error("user not found")
 		return entities.Employee{}, fmt.Errorf("user not found")
 	} else {
-		err := query.Scan(&row.ID, &row.Name, &row.Birthday)
+		err := scanEmployee(query, &row)
 		if err != nil {
 			e.log.Error("error scanning users", errMsg.Err(err))
 			return entities.Employee{}, err
@@ -50,7 +63,7 @@ func (e *EmployeeRepository) FindEmployeeByName(ctx context.Context, name string
 }
 
 func (e *EmployeeRepository) FindEmployeeById(ctx context.Context, id int) (entities.Employee, error) {
-	query, err := e.db.Query(ctx, `SELECT * FROM Employees WHERE id = $1`, id)
+	query, err := e.db.Query(ctx, `SELECT `+employeeColumns+` FROM Employees WHERE id = $1`, id)
 	if err != nil {
 		e.log.Error("error querying employees", errMsg.Err(err))
 		return entities.Employee{}, err
@@ -61,7 +74,7 @@ func (e *EmployeeRepository) FindEmployeeById(ctx context.Context, id int) (enti
 		e.log.Error("user not found")
 		return entities.Employee{}, nil
 	} else {
-		err := query.Scan(&rowArray.ID, &rowArray.Name, &rowArray.Birthday)
+		err := scanEmployee(query, &rowArray)
 		if err != nil {
 			e.log.Error("error scanning employees", errMsg.Err(err))
 			return entities.Employee{}, err
@@ -70,6 +83,16 @@ func (e *EmployeeRepository) FindEmployeeById(ctx context.Context, id int) (enti
 	return rowArray, nil
 }
 
+func (e *EmployeeRepository) UpdateEmployee(ctx context.Context, employee *entities.Employee) error {
+	_, err := e.db.Exec(ctx, `UPDATE Employees SET name = $1, birthday = $2 WHERE id = $3`,
+		employee.Name, employee.Birthday, employee.ID)
+	if err != nil {
+		e.log.Error("failed to update employee", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
 func (e *EmployeeRepository) DeleteEmpById(ctx context.Context, id int) error {
 	_, err := e.db.Exec(ctx, `DELETE FROM Employees WHERE id = $1`, id)
 	if err != nil {
@@ -79,8 +102,35 @@ func (e *EmployeeRepository) DeleteEmpById(ctx context.Context, id int) error {
 	return nil
 }
 
-func (e *EmployeeRepository) GetAllEmp(ctx context.Context) ([]entities.Employee, error) {
-	query, err := e.db.Query(ctx, `SELECT * FROM Employees`)
+func (e *EmployeeRepository) ArchiveEmpById(ctx context.Context, id int) error {
+	_, err := e.db.Exec(ctx, `UPDATE Employees SET archived_at = CURRENT_TIMESTAMP WHERE id = $1 AND archived_at IS NULL`, id)
+	if err != nil {
+		e.log.Error("failed to archive employee", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (e *EmployeeRepository) RestoreEmpById(ctx context.Context, id int) (bool, error) {
+	tag, err := e.db.Exec(ctx, `UPDATE Employees SET archived_at = NULL WHERE id = $1 AND archived_at IS NOT NULL`, id)
+	if err != nil {
+		e.log.Error("failed to restore employee", errMsg.Err(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (e *EmployeeRepository) PurgeArchived(ctx context.Context, archivedBefore time.Time) (int, error) {
+	tag, err := e.db.Exec(ctx, `DELETE FROM Employees WHERE archived_at < $1`, archivedBefore)
+	if err != nil {
+		e.log.Error("failed to purge archived employees", errMsg.Err(err))
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+func (e *EmployeeRepository) GetAllEmp(ctx context.Context, includeArchived bool) ([]entities.Employee, error) {
+	query, err := e.db.Query(ctx, `SELECT `+employeeColumns+` FROM Employees WHERE $1 OR archived_at IS NULL`, includeArchived)
 	if err != nil {
 		e.log.Error("Error querying employees", errMsg.Err(err))
 		return nil, err
@@ -90,7 +140,7 @@ func (e *EmployeeRepository) GetAllEmp(ctx context.Context) ([]entities.Employee
 	var employees []entities.Employee
 	for query.Next() {
 		var employee entities.Employee
-		err := query.Scan(&employee.ID, &employee.Name, &employee.Birthday)
+		err := scanEmployee(query, &employee)
 		if err != nil {
 			e.log.Error("Error scanning employees", errMsg.Err(err))
 			return nil, err
@@ -107,18 +157,93 @@ func (e *EmployeeRepository) GetAllEmp(ctx context.Context) ([]entities.Employee
 
 }
 
-func (e *EmployeeRepository) GetUpcomingBirthdays(ctx context.Context) ([]entities.Employee, error) {
-	var employees []entities.Employee
+func (e *EmployeeRepository) StreamEmployees(ctx context.Context, includeArchived bool, fn func(entities.Employee) error) error {
+	rows, err := e.db.Query(ctx, `SELECT `+employeeColumns+` FROM Employees WHERE $1 OR archived_at IS NULL ORDER BY id`,
+		includeArchived)
+	if err != nil {
+		e.log.Error("Error querying employees", errMsg.Err(err))
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var employee entities.Employee
+		if err := scanEmployee(rows, &employee); err != nil {
+			e.log.Error("Error scanning employees", errMsg.Err(err))
+			return err
+		}
+		if err := fn(employee); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// ImportEmployees upserts by external id inside one transaction; dryRun rolls it back.
+func (e *EmployeeRepository) ImportEmployees(ctx context.Context, employees []entities.Employee, dryRun bool) (created int, updated int, err error) {
+	tx, err := e.db.Begin(ctx)
+	if err != nil {
+		e.log.Error("failed to begin import transaction", errMsg.Err(err))
+		return 0, 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	for i := range employees {
+		employee := &employees[i]
+		inserted := true
+		if employee.ExternalID == "" {
+			err = tx.QueryRow(ctx, `INSERT INTO Employees (name, birthday, email) VALUES ($1, $2, NULLIF($3, '')) RETURNING id`,
+				employee.Name, employee.Birthday, employee.Email).Scan(&employee.ID)
+		} else {
+			err = tx.QueryRow(ctx, `INSERT INTO Employees (name, birthday, external_id, email) VALUES ($1, $2, $3, NULLIF($4, ''))
+				ON CONFLICT (external_id) DO UPDATE
+				SET name = EXCLUDED.name, birthday = EXCLUDED.birthday, email = COALESCE(EXCLUDED.email, Employees.email)
+				RETURNING id, (xmax = 0)`,
+				employee.Name, employee.Birthday, employee.ExternalID, employee.Email).Scan(&employee.ID, &inserted)
+		}
+		if err != nil {
+			e.log.Error("failed to import employee", slog.Int("index", i), errMsg.Err(err))
+			return 0, 0, fmt.Errorf("employee %q: %w", employee.Name, err)
+		}
+		if inserted {
+			created++
+		} else {
+			updated++
+		}
+	}
+
+	if dryRun {
+		return created, updated, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		e.log.Error("failed to commit import", errMsg.Err(err))
+		return 0, 0, err
+	}
+	return created, updated, nil
+}
 
-	query := `SELECT id, name, birthday
+// nextBirthdaysQuery selects active employees together with their next birthday on or after $1.
+// Employees who opted out of notifications are included; callers sending emails must skip them.
+// Feb 29 birthdays are celebrated on Feb 28 in non-leap years.
+const nextBirthdaysQuery = `SELECT id, name, birthday, birthday_visibility, notifications_opt_out, next_birthday
+	FROM (SELECT id, name, birthday, birthday_visibility, notifications_opt_out,
+			CASE WHEN this_year < $1::date
+				THEN (birthday + make_interval(years => age_years + 1))::date
+				ELSE this_year END AS next_birthday
+		FROM (SELECT id, name, birthday, birthday_visibility, notifications_opt_out,
+				EXTRACT(year FROM $1::date)::int - EXTRACT(year FROM birthday)::int AS age_years,
+				(birthday + make_interval(years => EXTRACT(year FROM $1::date)::int - EXTRACT(year FROM birthday)::int))::date AS this_year
 			FROM Employees
-			WHERE 
-    (birthday::date + INTERVAL '1 year' * (EXTRACT(year FROM CURRENT_DATE) - EXTRACT(year FROM birthday::date))) 
-    BETWEEN CURRENT_DATE AND (CURRENT_DATE + INTERVAL '7 day');
+			WHERE archived_at IS NULL) occurrences) upcoming`
+
+func (e *EmployeeRepository) GetUpcomingBirthdays(ctx context.Context, from, to time.Time) ([]entities.UpcomingBirthday, error) {
+	var birthdays []entities.UpcomingBirthday
 
-`
+	query := nextBirthdaysQuery + `
+		WHERE next_birthday BETWEEN $1::date AND $2::date
+		ORDER BY next_birthday, id`
 
-	rows, err := e.db.Query(ctx, query)
+	rows, err := e.db.Query(ctx, query, from, to)
 	if err != nil {
 		e.log.Error("failed to get umcoming birthdays", errMsg.Err(err))
 		return nil, err
@@ -126,14 +251,252 @@ func (e *EmployeeRepository) GetUpcomingBirthdays(ctx context.Context) ([]entiti
 	defer rows.Close()
 
 	for rows.Next() {
-		var employee entities.Employee
-		if err := rows.Scan(&employee.ID, &employee.Name, &employee.Birthday); err != nil {
+		var birthday entities.UpcomingBirthday
+		if err := rows.Scan(&birthday.ID, &birthday.Name, &birthday.Birthday, &birthday.Visibility, &birthday.OptOut,
+			&birthday.Date); err != nil {
 			e.log.Error("failed to scan employee", errMsg.Err(err))
 			return nil, err
 
 		}
-		employees = append(employees, employee)
+		birthday.Age = birthday.Date.Year() - birthday.Birthday.Year()
+		birthdays = append(birthdays, birthday)
 	}
 
-	return employees, nil
+	return birthdays, rows.Err()
+}
+
+// upcomingEventsQuery selects every event of active employees, birthdays included, together with
+// its next occurrence between $1 and $3. One-off events occur only on their date. Events of employees
+// who opted out of notifications are included with opt_out set.
+const upcomingEventsQuery = `SELECT * FROM (
+		SELECT *, CASE WHEN recurrence = 'once' THEN event_date
+				WHEN this_year < $1::date THEN (event_date + make_interval(years => age_years + 1))::date
+				ELSE this_year END AS next_date
+		FROM (SELECT *,
+				EXTRACT(year FROM $1::date)::int - EXTRACT(year FROM event_date)::int AS age_years,
+				(event_date + make_interval(years => EXTRACT(year FROM $1::date)::int - EXTRACT(year FROM event_date)::int))::date AS this_year
+			FROM (SELECT e.id, e.name, e.birthday, e.birthday_visibility, e.notifications_opt_out AS opt_out,
+					0 AS event_id, 'birthday' AS event_type, '' AS event_name, e.birthday AS event_date, 'yearly' AS recurrence
+				FROM Employees e
+				WHERE e.archived_at IS NULL
+				UNION ALL
+				SELECT e.id, e.name, e.birthday, e.birthday_visibility, e.notifications_opt_out,
+					ev.id, ev.type, ev.name, ev.date, ev.recurrence
+				FROM Events ev JOIN Employees e ON e.id = ev.emp_id
+				WHERE e.archived_at IS NULL) events) occurrences) upcoming
+	WHERE next_date BETWEEN $1::date AND $3::date`
+
+// GetUpcomingWithSubscribers resolves every event between from and to and its subscribers
+// (direct, team and reporting-line subscriptions) in one query. Users never hear about their own
+// events. Events of employees who opted out, users who unsubscribed or are muted at now, subscribers
+// whose subscriptions do not cover the event type and subscribers whose covering subscriptions are
+// all muted are returned flagged so the caller can tell why they are skipped.
+func (e *EmployeeRepository) GetUpcomingWithSubscribers(ctx context.Context, from, to, now time.Time) ([]entities.UpcomingEvent, error) {
+	query := `WITH RECURSIVE upcoming AS (` + upcomingEventsQuery + `),
+		upcoming_employees AS (SELECT DISTINCT id FROM upcoming),
+		managers AS (
+			SELECT e.id AS emp_id, e.manager_id AS id, 1 AS level, ARRAY[e.id, e.manager_id] AS path
+			FROM Employees e JOIN upcoming_employees up ON up.id = e.id
+			WHERE e.manager_id IS NOT NULL
+			UNION ALL
+			SELECT m.emp_id, e.manager_id, m.level + 1, m.path || e.manager_id
+			FROM Employees e JOIN managers m ON e.id = m.id
+			WHERE e.manager_id IS NOT NULL AND NOT e.manager_id = ANY(m.path)
+		),
+		recipients AS (
+			SELECT s.emp_id, s.user_id, s.id AS sub_id FROM Subscriptions s JOIN upcoming_employees up ON up.id = s.emp_id
+			UNION ALL
+			SELECT up.id, s.user_id, s.id FROM Subscriptions s CROSS JOIN upcoming_employees up WHERE s.all_employees
+			UNION ALL
+			SELECT tm.emp_id, s.user_id, s.id FROM Subscriptions s
+			JOIN TeamMembers tm ON tm.team_id = s.team_id
+			JOIN upcoming_employees up ON up.id = tm.emp_id
+			UNION ALL
+			SELECT m.emp_id, s.user_id, s.id FROM Subscriptions s
+			JOIN managers m ON m.id = s.reports_of AND (s.depth = 0 OR m.level <= s.depth)
+		)
+		SELECT up.id, up.name, up.birthday, up.birthday_visibility, up.opt_out,
+			up.event_id, up.event_type, up.event_name, up.event_date, up.recurrence,
+			u.id, u.email, u.digest_mode, u.quiet_hours_start, u.quiet_hours_end, u.timezone, u.delivery_hour,
+			u.unsubscribed_all, CASE WHEN u.muted_until > $2 THEN u.muted_until END,
+			r.sub_id, r.shift, r.covered, r.muted, EXISTS (SELECT 1 FROM WishlistItems w WHERE w.emp_id = up.id)
+		FROM upcoming up
+		JOIN LATERAL (SELECT rc.user_id,
+				COALESCE(MIN(rc.sub_id) FILTER (WHERE ms.covered AND NOT ms.muted),
+					MIN(rc.sub_id) FILTER (WHERE ms.covered), MIN(rc.sub_id)) AS sub_id,
+				COALESCE(bool_or(ms.shift_to_business_day) FILTER (WHERE ms.covered AND NOT ms.muted), FALSE) AS shift,
+				bool_or(ms.covered) AS covered,
+				COALESCE(bool_and(ms.muted) FILTER (WHERE ms.covered), FALSE) AS muted
+			FROM recipients rc
+			JOIN (SELECT id, up.event_type = ANY(event_types) AS covered, shift_to_business_day,
+					COALESCE(muted_until > $2, FALSE) AS muted
+				FROM Subscriptions) ms ON ms.id = rc.sub_id
+			WHERE rc.emp_id = up.id
+			GROUP BY rc.user_id) r ON TRUE
+		JOIN Users u ON u.id = r.user_id
+		WHERE (u.employee_id IS NULL OR u.employee_id <> up.id)
+		ORDER BY up.next_date, up.id, up.event_id, u.id`
+
+	rows, err := e.db.Query(ctx, query, from, now, to)
+	if err != nil {
+		e.log.Error("failed to get upcoming events with subscribers", errMsg.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var upcoming []entities.UpcomingEvent
+	for rows.Next() {
+		var (
+			employee    entities.Employee
+			event       entities.Event
+			subscriber  entities.Subscriber
+			hasWishlist bool
+		)
+		if err := rows.Scan(&employee.ID, &employee.Name, &employee.Birthday, &employee.Visibility, &employee.OptOut,
+			&event.ID, &event.Type, &event.Name, &event.Date, &event.Recurrence,
+			&subscriber.ID, &subscriber.Email, &subscriber.DigestMode,
+			&subscriber.QuietHoursStart, &subscriber.QuietHoursEnd, &subscriber.Timezone, &subscriber.DeliveryHour,
+			&subscriber.UnsubscribedAll, &subscriber.MutedUntil,
+			&subscriber.SubscriptionID, &subscriber.ShiftToBusinessDay, &subscriber.EventCovered, &subscriber.SubscriptionMuted,
+			&hasWishlist); err != nil {
+			e.log.Error("failed to scan upcoming event", errMsg.Err(err))
+			return nil, err
+		}
+		event.EmployeeID = employee.ID
+		if n := len(upcoming); n == 0 || upcoming[n-1].ID != employee.ID || upcoming[n-1].Event.ID != event.ID {
+			upcoming = append(upcoming, entities.UpcomingEvent{Employee: employee, Event: event, HasWishlist: hasWishlist})
+		}
+		last := &upcoming[len(upcoming)-1]
+		last.Subscribers = append(last.Subscribers, subscriber)
+	}
+	return upcoming, rows.Err()
+}
+
+const eventColumns = `id, emp_id, type, name, date, recurrence`
+
+// CreateEvent adds an event; an employee has one work anniversary, so saving another replaces its date.
+// It reports false when the employee does not exist or is archived.
+func (e *EmployeeRepository) CreateEvent(ctx context.Context, event *entities.Event) (bool, error) {
+	err := e.db.QueryRow(ctx, `INSERT INTO Events (emp_id, type, name, date, recurrence)
+		SELECT id, $2, $3, $4, $5 FROM Employees WHERE id = $1 AND archived_at IS NULL
+		ON CONFLICT (emp_id) WHERE type = 'work_anniversary'
+		DO UPDATE SET name = EXCLUDED.name, date = EXCLUDED.date, recurrence = EXCLUDED.recurrence
+		RETURNING id`,
+		event.EmployeeID, event.Type, event.Name, event.Date, event.Recurrence).Scan(&event.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		e.log.Error("failed to create event", errMsg.Err(err))
+		return false, err
+	}
+	return true, nil
+}
+
+func (e *EmployeeRepository) GetEvents(ctx context.Context, employeeID int) ([]entities.Event, error) {
+	rows, err := e.db.Query(ctx, `SELECT `+eventColumns+` FROM Events WHERE emp_id = $1 ORDER BY date, id`, employeeID)
+	if err != nil {
+		e.log.Error("failed to get events", errMsg.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []entities.Event
+	for rows.Next() {
+		var event entities.Event
+		if err := rows.Scan(&event.ID, &event.EmployeeID, &event.Type, &event.Name, &event.Date, &event.Recurrence); err != nil {
+			e.log.Error("failed to scan event", errMsg.Err(err))
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (e *EmployeeRepository) DeleteEvent(ctx context.Context, employeeID, id int) (bool, error) {
+	tag, err := e.db.Exec(ctx, `DELETE FROM Events WHERE id = $1 AND emp_id = $2`, id, employeeID)
+	if err != nil {
+		e.log.Error("failed to delete event", errMsg.Err(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (e *EmployeeRepository) SetManager(ctx context.Context, id, managerID int) error {
+	_, err := e.db.Exec(ctx, `UPDATE Employees SET manager_id = NULLIF($2, 0) WHERE id = $1`, id, managerID)
+	if err != nil {
+		e.log.Error("failed to set manager", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (e *EmployeeRepository) GetReportingChain(ctx context.Context, id int) ([]entities.OrgNode, error) {
+	query := `WITH RECURSIVE chain AS (
+		SELECT manager_id AS id, 1 AS level, ARRAY[id, manager_id] AS path
+		FROM Employees WHERE id = $1 AND manager_id IS NOT NULL
+		UNION ALL
+		SELECT e.manager_id, c.level + 1, c.path || e.manager_id
+		FROM Employees e JOIN chain c ON e.id = c.id
+		WHERE e.manager_id IS NOT NULL AND NOT e.manager_id = ANY(c.path)
+	)
+	SELECT ` + employeeColumns + `, c.level
+	FROM chain c JOIN Employees USING (id)
+	WHERE archived_at IS NULL
+	ORDER BY c.level`
+
+	return e.queryOrgNodes(ctx, query, id)
+}
+
+func (e *EmployeeRepository) GetReports(ctx context.Context, id int, depth int) ([]entities.OrgNode, error) {
+	query := `WITH RECURSIVE subtree AS (
+		SELECT id, 1 AS level, ARRAY[$1::int, id] AS path
+		FROM Employees WHERE manager_id = $1
+		UNION ALL
+		SELECT e.id, s.level + 1, s.path || e.id
+		FROM Employees e JOIN subtree s ON e.manager_id = s.id
+		WHERE NOT e.id = ANY(s.path) AND ($2 = 0 OR s.level < $2)
+	)
+	SELECT ` + employeeColumns + `, s.level
+	FROM subtree s JOIN Employees USING (id)
+	WHERE archived_at IS NULL
+	ORDER BY s.level, name`
+
+	return e.queryOrgNodes(ctx, query, id, depth)
+}
+
+func (e *EmployeeRepository) queryOrgNodes(ctx context.Context, query string, args ...any) ([]entities.OrgNode, error) {
+	rows, err := e.db.Query(ctx, query, args...)
+	if err != nil {
+		e.log.Error("failed to query org structure", errMsg.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var nodes []entities.OrgNode
+	for rows.Next() {
+		var node entities.OrgNode
+		err := rows.Scan(&node.ID, &node.Name, &node.Birthday, &node.ExternalID, &node.ManagerID, &node.Email,
+			&node.Visibility, &node.OptOut, &node.ArchivedAt, &node.Level)
+		if err != nil {
+			e.log.Error("failed to scan org structure", errMsg.Err(err))
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, rows.Err()
+}
+
+// SetPrivacy updates the privacy settings; an empty visibility or a nil optOut keeps the current value.
+func (e *EmployeeRepository) SetPrivacy(ctx context.Context, id int, visibility string, optOut *bool) error {
+	_, err := e.db.Exec(ctx, `UPDATE Employees
+		SET birthday_visibility = COALESCE(NULLIF($2, ''), birthday_visibility),
+			notifications_opt_out = COALESCE($3, notifications_opt_out)
+		WHERE id = $1`,
+		id, visibility, optOut)
+	if err != nil {
+		e.log.Error("failed to update employee privacy", errMsg.Err(err))
+		return err
+	}
+	return nil
 }
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"birthday-service/internal/database/migrations"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        fstest.MapFS
+		wantVersions []int64
+		wantErr      bool
+	}{
+		{
+			name: "ordered by version, not by name",
+			files: fstest.MapFS{
+				"10_ten.up.sql":  {Data: []byte("ten")},
+				"2_two.up.sql":   {Data: []byte("two")},
+				"2_two.down.sql": {Data: []byte("undo two")},
+				"1_one.up.sql":   {Data: []byte("one")},
+				"README.md":      {Data: []byte("ignored")},
+			},
+			wantVersions: []int64{1, 2, 10},
+		},
+		{
+			name:    "down without up",
+			files:   fstest.MapFS{"1_one.down.sql": {Data: []byte("undo")}},
+			wantErr: true,
+		},
+		{
+			name: "conflicting names",
+			files: fstest.MapFS{
+				"1_one.up.sql":   {Data: []byte("one")},
+				"1_other.up.sql": {Data: []byte("other")},
+			},
+			wantErr: true,
+		},
+		{
+			name:         "empty",
+			files:        fstest.MapFS{},
+			wantVersions: []int64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadMigrations(tt.files)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadMigrations error = %v, want error %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.wantVersions) {
+				t.Fatalf("LoadMigrations returned %d migrations, want %d", len(got), len(tt.wantVersions))
+			}
+			for i, version := range tt.wantVersions {
+				if got[i].Version != version {
+					t.Fatalf("migration %d has version %d, want %d", i, got[i].Version, version)
+				}
+			}
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	loaded, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		t.Fatalf("LoadMigrations: %v", err)
+	}
+	for i, migration := range loaded {
+		if migration.Version != int64(i+1) {
+			t.Fatalf("migration %d_%s breaks the sequence at position %d", migration.Version, migration.Name, i+1)
+		}
+		if migration.Down == "" {
+			t.Fatalf("migration %d_%s has no down script", migration.Version, migration.Name)
+		}
+	}
+}
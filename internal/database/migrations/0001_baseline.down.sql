@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS Subscriptions;
+DROP TABLE IF EXISTS Employees;
+DROP TABLE IF EXISTS Users;
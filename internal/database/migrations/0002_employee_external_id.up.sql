@@ -0,0 +1 @@
+ALTER TABLE Employees ADD COLUMN IF NOT EXISTS external_id VARCHAR(100) UNIQUE;
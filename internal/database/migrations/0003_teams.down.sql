@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS subscriptions_user_team_key;
+DELETE FROM Subscriptions WHERE team_id IS NOT NULL;
+ALTER TABLE Subscriptions DROP COLUMN IF EXISTS team_id;
+DROP TABLE IF EXISTS TeamMembers;
+DROP TABLE IF EXISTS Teams;
+DROP TABLE IF EXISTS Departments;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS Departments (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) UNIQUE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS Teams (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    department_id INTEGER REFERENCES Departments(id) ON DELETE SET NULL,
+    UNIQUE(department_id, name)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS teams_name_no_department_key ON Teams(name) WHERE department_id IS NULL;
+
+CREATE TABLE IF NOT EXISTS TeamMembers (
+    team_id INTEGER REFERENCES Teams(id) ON DELETE CASCADE,
+    emp_id INTEGER REFERENCES Employees(id) ON DELETE CASCADE,
+    PRIMARY KEY(team_id, emp_id)
+);
+
+ALTER TABLE Subscriptions ADD COLUMN IF NOT EXISTS team_id INTEGER REFERENCES Teams(id) ON DELETE CASCADE;
+CREATE UNIQUE INDEX IF NOT EXISTS subscriptions_user_team_key ON Subscriptions(user_id, team_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS subscriptions_user_reports_key;
+DELETE FROM Subscriptions WHERE reports_of IS NOT NULL;
+ALTER TABLE Subscriptions DROP COLUMN IF EXISTS depth;
+ALTER TABLE Subscriptions DROP COLUMN IF EXISTS reports_of;
+ALTER TABLE Employees DROP COLUMN IF EXISTS manager_id;
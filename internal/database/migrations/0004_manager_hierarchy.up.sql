@@ -0,0 +1,5 @@
+ALTER TABLE Employees ADD COLUMN IF NOT EXISTS manager_id INTEGER REFERENCES Employees(id) ON DELETE SET NULL;
+
+ALTER TABLE Subscriptions ADD COLUMN IF NOT EXISTS reports_of INTEGER REFERENCES Employees(id) ON DELETE CASCADE;
+ALTER TABLE Subscriptions ADD COLUMN IF NOT EXISTS depth INTEGER NOT NULL DEFAULT 0;
+CREATE UNIQUE INDEX IF NOT EXISTS subscriptions_user_reports_key ON Subscriptions(user_id, reports_of);
@@ -0,0 +1,3 @@
+ALTER TABLE Users DROP COLUMN IF EXISTS is_admin;
+ALTER TABLE Users DROP COLUMN IF EXISTS employee_id;
+ALTER TABLE Employees DROP COLUMN IF EXISTS email;
@@ -0,0 +1,3 @@
+ALTER TABLE Employees ADD COLUMN IF NOT EXISTS email VARCHAR(100) UNIQUE;
+ALTER TABLE Users ADD COLUMN IF NOT EXISTS employee_id INTEGER UNIQUE REFERENCES Employees(id) ON DELETE SET NULL;
+ALTER TABLE Users ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,2 @@
+ALTER TABLE Employees DROP COLUMN IF EXISTS notifications_opt_out;
+ALTER TABLE Employees DROP COLUMN IF EXISTS birthday_visibility;
@@ -0,0 +1,2 @@
+ALTER TABLE Employees ADD COLUMN IF NOT EXISTS birthday_visibility VARCHAR(10) NOT NULL DEFAULT 'full';
+ALTER TABLE Employees ADD COLUMN IF NOT EXISTS notifications_opt_out BOOLEAN NOT NULL DEFAULT false;
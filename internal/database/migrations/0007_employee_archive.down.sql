@@ -0,0 +1 @@
+ALTER TABLE Employees DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1 @@
+ALTER TABLE Employees ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS delivery_log;
+
+ALTER TABLE Users DROP COLUMN IF EXISTS digest_mode;
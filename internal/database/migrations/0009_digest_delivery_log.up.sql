@@ -0,0 +1,12 @@
+ALTER TABLE Users ADD COLUMN IF NOT EXISTS digest_mode VARCHAR(16) NOT NULL DEFAULT 'immediate';
+
+CREATE TABLE IF NOT EXISTS delivery_log (
+    recipient VARCHAR(100) NOT NULL,
+    event_key VARCHAR(255) NOT NULL,
+    occurrence DATE NOT NULL,
+    outbox_id BIGINT REFERENCES notification_outbox(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (recipient, event_key, occurrence)
+);
+
+CREATE INDEX IF NOT EXISTS delivery_log_occurrence_idx ON delivery_log (occurrence);
@@ -0,0 +1,3 @@
+ALTER TABLE notification_outbox DROP COLUMN IF EXISTS headers;
+
+ALTER TABLE Users DROP COLUMN IF EXISTS unsubscribed_all;
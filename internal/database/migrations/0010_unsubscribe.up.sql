@@ -0,0 +1,3 @@
+ALTER TABLE Users ADD COLUMN IF NOT EXISTS unsubscribed_all BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE notification_outbox ADD COLUMN IF NOT EXISTS headers JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS subscriptions_user_all_key;
+ALTER TABLE Subscriptions DROP COLUMN IF EXISTS all_employees;
@@ -0,0 +1,2 @@
+ALTER TABLE Subscriptions ADD COLUMN IF NOT EXISTS all_employees BOOLEAN NOT NULL DEFAULT false;
+CREATE UNIQUE INDEX IF NOT EXISTS subscriptions_user_all_key ON Subscriptions(user_id) WHERE all_employees;
@@ -0,0 +1,5 @@
+ALTER TABLE Subscriptions DROP COLUMN IF EXISTS muted_until;
+
+ALTER TABLE Users DROP COLUMN IF EXISTS quiet_hours_end;
+ALTER TABLE Users DROP COLUMN IF EXISTS quiet_hours_start;
+ALTER TABLE Users DROP COLUMN IF EXISTS muted_until;
@@ -0,0 +1,5 @@
+ALTER TABLE Users ADD COLUMN IF NOT EXISTS muted_until TIMESTAMPTZ;
+ALTER TABLE Users ADD COLUMN IF NOT EXISTS quiet_hours_start SMALLINT CHECK (quiet_hours_start BETWEEN 0 AND 23);
+ALTER TABLE Users ADD COLUMN IF NOT EXISTS quiet_hours_end SMALLINT CHECK (quiet_hours_end BETWEEN 0 AND 23);
+
+ALTER TABLE Subscriptions ADD COLUMN IF NOT EXISTS muted_until TIMESTAMPTZ;
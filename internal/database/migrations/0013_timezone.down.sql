@@ -0,0 +1,2 @@
+ALTER TABLE Users DROP COLUMN IF EXISTS delivery_hour;
+ALTER TABLE Users DROP COLUMN IF EXISTS timezone;
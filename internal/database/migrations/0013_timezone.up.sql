@@ -0,0 +1,2 @@
+ALTER TABLE Users ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT 'UTC';
+ALTER TABLE Users ADD COLUMN IF NOT EXISTS delivery_hour SMALLINT CHECK (delivery_hour BETWEEN 0 AND 23);
@@ -0,0 +1,3 @@
+ALTER TABLE Subscriptions DROP COLUMN IF EXISTS shift_to_business_day;
+
+DROP TABLE IF EXISTS Holidays;
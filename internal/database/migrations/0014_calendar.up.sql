@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS Holidays (
+    day DATE PRIMARY KEY,
+    name TEXT NOT NULL DEFAULT '',
+    working BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+ALTER TABLE Subscriptions ADD COLUMN IF NOT EXISTS shift_to_business_day BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,3 @@
+ALTER TABLE Subscriptions DROP COLUMN IF EXISTS event_types;
+
+DROP TABLE IF EXISTS Events;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS Events (
+    id SERIAL PRIMARY KEY,
+    emp_id INT NOT NULL REFERENCES Employees(id) ON DELETE CASCADE,
+    type VARCHAR(32) NOT NULL CHECK (type IN ('work_anniversary', 'custom')),
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    date DATE NOT NULL,
+    recurrence VARCHAR(16) NOT NULL DEFAULT 'yearly' CHECK (recurrence IN ('yearly', 'once'))
+);
+
+CREATE INDEX IF NOT EXISTS events_emp_id_idx ON Events (emp_id);
+CREATE UNIQUE INDEX IF NOT EXISTS events_work_anniversary_idx ON Events (emp_id) WHERE type = 'work_anniversary';
+
+ALTER TABLE Subscriptions ADD COLUMN IF NOT EXISTS event_types TEXT[] NOT NULL DEFAULT '{birthday}';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS Pledges;
+DROP TABLE IF EXISTS Collections;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS Collections (
+    id SERIAL PRIMARY KEY,
+    emp_id INT NOT NULL REFERENCES Employees(id) ON DELETE CASCADE,
+    occurrence DATE NOT NULL,
+    organiser_id INT NOT NULL REFERENCES Users(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    target INT NOT NULL DEFAULT 0 CHECK (target >= 0),
+    closed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (emp_id, occurrence)
+);
+
+CREATE TABLE IF NOT EXISTS Pledges (
+    collection_id INT NOT NULL REFERENCES Collections(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES Users(id) ON DELETE CASCADE,
+    amount INT NOT NULL CHECK (amount > 0),
+    paid_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (collection_id, user_id)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS BoardMessages (
+    id SERIAL PRIMARY KEY,
+    emp_id INT NOT NULL REFERENCES Employees(id) ON DELETE CASCADE,
+    year INT NOT NULL,
+    author_id INT NOT NULL REFERENCES Users(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (emp_id, year, author_id)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS WishlistItems (
+    id SERIAL PRIMARY KEY,
+    emp_id INT NOT NULL REFERENCES Employees(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    url TEXT NOT NULL DEFAULT '',
+    price_min INT CHECK (price_min >= 0),
+    price_max INT CHECK (price_max >= 0),
+    reserved_by INT REFERENCES Users(id) ON DELETE SET NULL,
+    reserved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (price_min IS NULL OR price_max IS NULL OR price_min <= price_max)
+);
+
+CREATE INDEX IF NOT EXISTS wishlist_items_emp_id_idx ON WishlistItems (emp_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS notification_attempts;
+ALTER TABLE notification_outbox DROP COLUMN IF EXISTS emp_id;
@@ -0,0 +1,18 @@
+ALTER TABLE notification_outbox ADD COLUMN IF NOT EXISTS emp_id INT REFERENCES Employees(id) ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS notification_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    outbox_id BIGINT REFERENCES notification_outbox(id) ON DELETE SET NULL,
+    emp_id INT REFERENCES Employees(id) ON DELETE SET NULL,
+    recipient VARCHAR(100) NOT NULL,
+    subject TEXT NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL CHECK (status IN ('queued', 'sent', 'failed', 'bounced', 'skipped')),
+    reason TEXT NOT NULL DEFAULT '',
+    provider_response TEXT NOT NULL DEFAULT '',
+    dedup_key TEXT UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS notification_attempts_recipient_idx ON notification_attempts (recipient, created_at);
+CREATE INDEX IF NOT EXISTS notification_attempts_emp_id_idx ON notification_attempts (emp_id, created_at);
+CREATE INDEX IF NOT EXISTS notification_attempts_created_at_idx ON notification_attempts (created_at);
@@ -0,0 +1,280 @@
+package database
+
+import (
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	StatusPending = "pending"
+	StatusSending = "sending"
+	StatusSent    = "sent"
+	StatusDead    = "dead"
+)
+
+const outboxColumns = `id, COALESCE(emp_id, 0), recipient, subject, body, headers, dedup_key, status, attempts, COALESCE(last_error, ''),
+	next_attempt_at, created_at, sent_at`
+
+type OutboxRepository struct {
+	db  *pgxpool.Pool
+	log *slog.Logger
+}
+
+func NewOutboxRepository(db *pgxpool.Pool, log *slog.Logger) *OutboxRepository {
+	return &OutboxRepository{db, log}
+}
+
+// Enqueue stores messages for delivery together with their delivery log entries; messages whose
+// dedup key is already known are skipped and their deliveries are not logged.
+func (o *OutboxRepository) Enqueue(ctx context.Context, messages []entities.OutboxMessage) (int, error) {
+	tx, err := o.db.Begin(ctx)
+	if err != nil {
+		o.log.Error("failed to begin transaction", errMsg.Err(err))
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	enqueued := 0
+	for _, message := range messages {
+		var id int64
+		headers := message.Headers
+		if headers == nil {
+			headers = map[string]string{}
+		}
+		err := tx.QueryRow(ctx, `INSERT INTO notification_outbox (emp_id, recipient, subject, body, headers, dedup_key)
+			VALUES (NULLIF($1, 0), $2, $3, $4, $5, $6) ON CONFLICT (dedup_key) DO NOTHING RETURNING id`,
+			message.EmployeeID, message.Recipient, message.Subject, message.Body, headers, message.DedupKey).Scan(&id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			_, err := tx.Exec(ctx, `INSERT INTO notification_attempts (emp_id, recipient, subject, status, reason, dedup_key)
+				VALUES (NULLIF($1, 0), $2, $3, 'skipped', 'a notification with the same key was already queued', $4)
+				ON CONFLICT (dedup_key) DO NOTHING`,
+				message.EmployeeID, message.Recipient, message.Subject, "duplicate:"+message.DedupKey)
+			if err != nil {
+				o.log.Error("failed to record skipped message", errMsg.Err(err))
+				return 0, err
+			}
+			continue
+		}
+		if err != nil {
+			o.log.Error("failed to enqueue message", errMsg.Err(err))
+			return 0, err
+		}
+		_, err = tx.Exec(ctx, `INSERT INTO notification_attempts (outbox_id, emp_id, recipient, subject, status)
+			VALUES ($1, NULLIF($2, 0), $3, $4, 'queued')`, id, message.EmployeeID, message.Recipient, message.Subject)
+		if err != nil {
+			o.log.Error("failed to record queued message", errMsg.Err(err))
+			return 0, err
+		}
+		for _, delivery := range message.Deliveries {
+			_, err := tx.Exec(ctx, `INSERT INTO delivery_log (recipient, event_key, occurrence, outbox_id)
+				VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING`,
+				delivery.Recipient, delivery.EventKey, delivery.Occurrence, id)
+			if err != nil {
+				o.log.Error("failed to log delivery", errMsg.Err(err))
+				return 0, err
+			}
+		}
+		enqueued++
+	}
+	if err := tx.Commit(ctx); err != nil {
+		o.log.Error("failed to commit outbox messages", errMsg.Err(err))
+		return 0, err
+	}
+	return enqueued, nil
+}
+
+// GetDeliveries returns deliveries of occurrences between from and to inclusive.
+func (o *OutboxRepository) GetDeliveries(ctx context.Context, from, to time.Time) ([]entities.Delivery, error) {
+	rows, err := o.db.Query(ctx, `SELECT recipient, event_key, occurrence FROM delivery_log
+		WHERE occurrence BETWEEN $1::date AND $2::date`, from, to)
+	if err != nil {
+		o.log.Error("failed to get deliveries", errMsg.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []entities.Delivery
+	for rows.Next() {
+		var delivery entities.Delivery
+		if err := rows.Scan(&delivery.Recipient, &delivery.EventKey, &delivery.Occurrence); err != nil {
+			o.log.Error("failed to scan delivery", errMsg.Err(err))
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// Claim locks up to limit due messages for lease and counts the attempt. Messages whose lease
+// expired while sending (e.g. the worker crashed) are claimed again.
+func (o *OutboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]entities.OutboxMessage, error) {
+	rows, err := o.db.Query(ctx, `UPDATE notification_outbox
+		SET status = 'sending', attempts = attempts + 1, locked_until = now() + $2::interval
+		WHERE id IN (SELECT id FROM notification_outbox
+			WHERE (status = 'pending' AND next_attempt_at <= now())
+			   OR (status = 'sending' AND locked_until < now())
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED)
+		RETURNING `+outboxColumns, limit, lease)
+	if err != nil {
+		o.log.Error("failed to claim outbox messages", errMsg.Err(err))
+		return nil, err
+	}
+	return o.scanMessages(rows)
+}
+
+// withAttempt turns an UPDATE of one outbox message ($1) into a statement that also records the
+// attempt in the notification history with reason $2 and provider response $3.
+func withAttempt(update, status string) string {
+	return `WITH updated AS (` + update + ` RETURNING id, emp_id, recipient, subject)
+		INSERT INTO notification_attempts (outbox_id, emp_id, recipient, subject, status, reason, provider_response)
+		SELECT id, emp_id, recipient, subject, '` + status + `', $2::text, $3::text FROM updated`
+}
+
+func (o *OutboxRepository) MarkSent(ctx context.Context, id int64, response string) error {
+	_, err := o.db.Exec(ctx, withAttempt(`UPDATE notification_outbox
+		SET status = 'sent', sent_at = now(), locked_until = NULL, last_error = NULL WHERE id = $1`, entities.AttemptSent),
+		id, "", response)
+	if err != nil {
+		o.log.Error("failed to mark message sent", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+// MarkFailed schedules another attempt at nextAttempt.
+func (o *OutboxRepository) MarkFailed(ctx context.Context, id int64, lastError, response string, nextAttempt time.Time) error {
+	_, err := o.db.Exec(ctx, withAttempt(`UPDATE notification_outbox
+		SET status = 'pending', last_error = $2, next_attempt_at = $4, locked_until = NULL WHERE id = $1`, entities.AttemptFailed),
+		id, lastError, response, nextAttempt)
+	if err != nil {
+		o.log.Error("failed to mark message failed", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (o *OutboxRepository) MarkDead(ctx context.Context, id int64, lastError, response string) error {
+	_, err := o.db.Exec(ctx, withAttempt(`UPDATE notification_outbox
+		SET status = 'dead', last_error = $2, locked_until = NULL WHERE id = $1`, entities.AttemptFailed),
+		id, lastError, response)
+	if err != nil {
+		o.log.Error("failed to mark message dead", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+// MarkBounced moves a message the mail server rejected permanently to the dead letters without
+// further attempts.
+func (o *OutboxRepository) MarkBounced(ctx context.Context, id int64, lastError, response string) error {
+	_, err := o.db.Exec(ctx, withAttempt(`UPDATE notification_outbox
+		SET status = 'dead', last_error = $2, locked_until = NULL WHERE id = $1`, entities.AttemptBounced),
+		id, lastError, response)
+	if err != nil {
+		o.log.Error("failed to mark message bounced", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (o *OutboxRepository) ListOutbox(ctx context.Context, status string, limit int) ([]entities.OutboxMessage, error) {
+	rows, err := o.db.Query(ctx, `SELECT `+outboxColumns+` FROM notification_outbox
+		WHERE $1 = '' OR status = $1
+		ORDER BY id DESC
+		LIMIT $2`, status, limit)
+	if err != nil {
+		o.log.Error("failed to list outbox", errMsg.Err(err))
+		return nil, err
+	}
+	return o.scanMessages(rows)
+}
+
+// Resend puts a dead or already sent message back into the queue with a fresh attempt budget.
+func (o *OutboxRepository) Resend(ctx context.Context, id int64) (bool, error) {
+	tag, err := o.db.Exec(ctx, withAttempt(`UPDATE notification_outbox
+		SET status = 'pending', attempts = 0, last_error = NULL, next_attempt_at = now(), sent_at = NULL
+		WHERE id = $1 AND status IN ('dead', 'sent')`, entities.AttemptQueued),
+		id, "requeued by an administrator", "")
+	if err != nil {
+		o.log.Error("failed to resend message", errMsg.Err(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (o *OutboxRepository) scanMessages(rows pgx.Rows) ([]entities.OutboxMessage, error) {
+	defer rows.Close()
+	var messages []entities.OutboxMessage
+	for rows.Next() {
+		var message entities.OutboxMessage
+		if err := rows.Scan(&message.ID, &message.EmployeeID, &message.Recipient, &message.Subject, &message.Body, &message.Headers, &message.DedupKey,
+			&message.Status, &message.Attempts, &message.LastError, &message.NextAttemptAt, &message.CreatedAt,
+			&message.SentAt); err != nil {
+			o.log.Error("failed to scan outbox message", errMsg.Err(err))
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, rows.Err()
+}
+
+// RecordSkipped adds notifications that were deliberately not sent to the history; attempts with
+// a dedup key already recorded are ignored, so repeated runs do not pile up entries.
+func (o *OutboxRepository) RecordSkipped(ctx context.Context, attempts []entities.NotificationAttempt) error {
+	if len(attempts) == 0 {
+		return nil
+	}
+	batch := &pgx.Batch{}
+	for _, attempt := range attempts {
+		batch.Queue(`INSERT INTO notification_attempts (emp_id, recipient, subject, status, reason, dedup_key)
+			VALUES (NULLIF($1, 0), $2, $3, 'skipped', $4, NULLIF($5, ''))
+			ON CONFLICT (dedup_key) DO NOTHING`,
+			attempt.EmployeeID, attempt.Recipient, attempt.Subject, attempt.Reason, attempt.DedupKey)
+	}
+	if err := o.db.SendBatch(ctx, batch).Close(); err != nil {
+		o.log.Error("failed to record skipped notifications", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+// SearchAttempts returns the newest history entries matching filter.
+func (o *OutboxRepository) SearchAttempts(ctx context.Context, filter entities.AttemptFilter) ([]entities.NotificationAttempt, error) {
+	rows, err := o.db.Query(ctx, `SELECT a.id, a.outbox_id, COALESCE(a.emp_id, 0), COALESCE(e.name, ''), a.recipient,
+			a.subject, a.status, a.reason, a.provider_response, a.created_at
+		FROM notification_attempts a
+		LEFT JOIN Employees e ON e.id = a.emp_id
+		WHERE ($1 = 0 OR a.emp_id = $1)
+		  AND ($2 = '' OR a.recipient = $2)
+		  AND ($3 = '' OR a.status = $3)
+		  AND ($4::timestamptz IS NULL OR a.created_at >= $4)
+		  AND ($5::timestamptz IS NULL OR a.created_at < $5)
+		ORDER BY a.created_at DESC, a.id DESC
+		LIMIT $6`, filter.EmployeeID, filter.Recipient, filter.Status, filter.From, filter.To, filter.Limit)
+	if err != nil {
+		o.log.Error("failed to search notification history", errMsg.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []entities.NotificationAttempt
+	for rows.Next() {
+		var attempt entities.NotificationAttempt
+		if err := rows.Scan(&attempt.ID, &attempt.OutboxID, &attempt.EmployeeID, &attempt.EmployeeName, &attempt.Recipient,
+			&attempt.Subject, &attempt.Status, &attempt.Reason, &attempt.ProviderResponse, &attempt.CreatedAt); err != nil {
+			o.log.Error("failed to scan notification attempt", errMsg.Err(err))
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+	return attempts, rows.Err()
+}
@@ -4,8 +4,11 @@ import (
 	"birthday-service/internal/entities"
 	errMsg "birthday-service/internal/err"
 	"context"
+	"errors"
 	"log/slog"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -18,30 +21,153 @@ func NewSubsRepository(db *pgxpool.Pool, log *slog.Logger) *SubsRepository {
 	return &SubsRepository{db, log}
 }
 
-func (s *SubsRepository) CreateSub(ctx context.Context, sub *entities.Subscription) error {
-	err := s.db.QueryRow(ctx, `INSERT INTO Subscriptions (user_id, emp_id) VALUES ($1, $2) RETURNING ID`, sub.UserID, sub.EmployeeID).Scan(&sub.ID)
-	if err != nil {
+// CreateSub is idempotent: when the user already has a subscription to the same target it is
+// returned in sub and created is false.
+func (s *SubsRepository) CreateSub(ctx context.Context, sub *entities.Subscription) (bool, error) {
+	if len(sub.EventTypes) == 0 {
+		sub.EventTypes = []string{entities.EventBirthday}
+	}
+	err := s.db.QueryRow(ctx, `INSERT INTO Subscriptions (user_id, emp_id, team_id, reports_of, depth, all_employees,
+			shift_to_business_day, event_types)
+		VALUES ($1, NULLIF($2, 0), NULLIF($3, 0), NULLIF($4, 0), $5, $6, $7, $8)
+		ON CONFLICT DO NOTHING RETURNING id`,
+		sub.UserID, sub.EmployeeID, sub.TeamID, sub.ReportsOf, sub.Depth, sub.AllEmployees, sub.ShiftToBusinessDay,
+		sub.EventTypes).Scan(&sub.ID)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
 		s.log.Error("failed to create subscription", errMsg.Err(err))
-		return err
+		return false, err
+	}
+
+	// The subscription exists already and is returned as stored; UpdateSubOptions changes its options.
+	err = s.db.QueryRow(ctx, `SELECT id, depth, shift_to_business_day, event_types FROM Subscriptions
+		WHERE user_id = $1
+		  AND emp_id IS NOT DISTINCT FROM NULLIF($2, 0)
+		  AND team_id IS NOT DISTINCT FROM NULLIF($3, 0)
+		  AND reports_of IS NOT DISTINCT FROM NULLIF($4, 0)
+		  AND all_employees = $5`,
+		sub.UserID, sub.EmployeeID, sub.TeamID, sub.ReportsOf, sub.AllEmployees).
+		Scan(&sub.ID, &sub.Depth, &sub.ShiftToBusinessDay, &sub.EventTypes)
+	if err != nil {
+		s.log.Error("failed to find existing subscription", errMsg.Err(err))
+		return false, err
 	}
-	return nil
+	return false, nil
 }
 
-func (s *SubsRepository) DeleteSub(ctx context.Context, id int) error {
-	_, err := s.db.Exec(ctx, `DELETE FROM Subscriptions WHERE id = $1`, id)
+// CreateSubs subscribes the user to every listed active employee and returns how many
+// subscriptions were new.
+func (s *SubsRepository) CreateSubs(ctx context.Context, userID int, employeeIDs []int) (int, error) {
+	tag, err := s.db.Exec(ctx, `INSERT INTO Subscriptions (user_id, emp_id)
+		SELECT $1, e.id FROM Employees e WHERE e.id = ANY($2) AND e.archived_at IS NULL
+		ON CONFLICT DO NOTHING`, userID, employeeIDs)
 	if err != nil {
-		s.log.Error("failed to delete user", errMsg.Err(err))
-		return err
+		s.log.Error("failed to create subscriptions", errMsg.Err(err))
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+func (s *SubsRepository) DeleteSubsByEmployees(ctx context.Context, userID int, employeeIDs []int) (int, error) {
+	tag, err := s.db.Exec(ctx, `DELETE FROM Subscriptions WHERE user_id = $1 AND emp_id = ANY($2)`, userID, employeeIDs)
+	if err != nil {
+		s.log.Error("failed to delete subscriptions", errMsg.Err(err))
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// DeleteUserSub removes the subscription only if it belongs to userID.
+func (s *SubsRepository) DeleteUserSub(ctx context.Context, userID, id int) (bool, error) {
+	tag, err := s.db.Exec(ctx, `DELETE FROM Subscriptions WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		s.log.Error("failed to delete subscription", errMsg.Err(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// DeleteSubAsAdmin removes the subscription whoever owns it.
+func (s *SubsRepository) DeleteSubAsAdmin(ctx context.Context, id int) (bool, error) {
+	tag, err := s.db.Exec(ctx, `DELETE FROM Subscriptions WHERE id = $1`, id)
+	if err != nil {
+		s.log.Error("failed to delete subscription", errMsg.Err(err))
+		return false, err
 	}
-	return nil
+	return tag.RowsAffected() > 0, nil
+}
+
+// UpdateSubOptions changes the reminder options of one of the user's subscriptions; nil options are kept.
+func (s *SubsRepository) UpdateSubOptions(ctx context.Context, userID, id int, shiftToBusinessDay *bool, eventTypes []string, depth *int) (bool, error) {
+	tag, err := s.db.Exec(ctx, `UPDATE Subscriptions
+		SET shift_to_business_day = COALESCE($3, shift_to_business_day),
+			event_types = COALESCE($4, event_types),
+			depth = CASE WHEN reports_of IS NULL THEN depth ELSE COALESCE($5, depth) END
+		WHERE id = $1 AND user_id = $2`,
+		id, userID, shiftToBusinessDay, eventTypes, depth)
+	if err != nil {
+		s.log.Error("failed to update subscription options", errMsg.Err(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// UpdateSubOptionsAsAdmin changes the reminder options of the subscription whoever owns it.
+func (s *SubsRepository) UpdateSubOptionsAsAdmin(ctx context.Context, id int, shiftToBusinessDay *bool, eventTypes []string, depth *int) (bool, error) {
+	tag, err := s.db.Exec(ctx, `UPDATE Subscriptions
+		SET shift_to_business_day = COALESCE($2, shift_to_business_day),
+			event_types = COALESCE($3, event_types),
+			depth = CASE WHEN reports_of IS NULL THEN depth ELSE COALESCE($4, depth) END
+		WHERE id = $1`,
+		id, shiftToBusinessDay, eventTypes, depth)
+	if err != nil {
+		s.log.Error("failed to update subscription options", errMsg.Err(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// SetSubMutedUntil pauses one of the user's subscriptions until the given time; nil unmutes.
+func (s *SubsRepository) SetSubMutedUntil(ctx context.Context, userID, id int, until *time.Time) (bool, error) {
+	tag, err := s.db.Exec(ctx, `UPDATE Subscriptions SET muted_until = $3 WHERE id = $1 AND user_id = $2`,
+		id, userID, until)
+	if err != nil {
+		s.log.Error("failed to update subscription mute", errMsg.Err(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// SetSubMutedUntilAsAdmin pauses the subscription whoever owns it; nil unmutes.
+func (s *SubsRepository) SetSubMutedUntilAsAdmin(ctx context.Context, id int, until *time.Time) (bool, error) {
+	tag, err := s.db.Exec(ctx, `UPDATE Subscriptions SET muted_until = $2 WHERE id = $1`, id, until)
+	if err != nil {
+		s.log.Error("failed to update subscription mute", errMsg.Err(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
 }
 
 func (s *SubsRepository) GetSubs(ctx context.Context, EmployeeID int) ([]entities.User, error) {
 	var users []entities.User
-	query := `SELECT u.id, u.email
+	query := `WITH RECURSIVE managers AS (
+			SELECT manager_id AS id, 1 AS level, ARRAY[id, manager_id] AS path
+			FROM Employees WHERE id = $1 AND manager_id IS NOT NULL
+			UNION ALL
+			SELECT e.manager_id, m.level + 1, m.path || e.manager_id
+			FROM Employees e JOIN managers m ON e.id = m.id
+			WHERE e.manager_id IS NOT NULL AND NOT e.manager_id = ANY(m.path)
+		)
+		SELECT DISTINCT u.id, u.email
 		FROM Users u
 		JOIN Subscriptions s ON u.id = s.user_id
-		WHERE s.emp_id = $1`
+		WHERE (u.employee_id IS NULL OR u.employee_id <> $1) AND NOT u.unsubscribed_all
+		  AND (s.emp_id = $1
+		   OR s.all_employees
+		   OR s.team_id IN (SELECT team_id FROM TeamMembers WHERE emp_id = $1)
+		   OR EXISTS (SELECT 1 FROM managers m WHERE m.id = s.reports_of AND (s.depth = 0 OR m.level <= s.depth)))`
 
 	rows, err := s.db.Query(ctx, query, EmployeeID)
 	if err != nil {
@@ -61,3 +187,62 @@ func (s *SubsRepository) GetSubs(ctx context.Context, EmployeeID int) ([]entitie
 
 	return users, nil
 }
+
+// IsSubscribed reports whether any of the user's subscriptions covers the active employee. Unlike
+// GetSubs it ignores unsubscribed_all: opting out of mail does not take away access.
+func (s *SubsRepository) IsSubscribed(ctx context.Context, userID, employeeID int) (bool, error) {
+	var subscribed bool
+	err := s.db.QueryRow(ctx, `WITH RECURSIVE managers AS (
+			SELECT manager_id AS id, 1 AS level, ARRAY[id, manager_id] AS path
+			FROM Employees WHERE id = $2 AND manager_id IS NOT NULL
+			UNION ALL
+			SELECT e.manager_id, m.level + 1, m.path || e.manager_id
+			FROM Employees e JOIN managers m ON e.id = m.id
+			WHERE e.manager_id IS NOT NULL AND NOT e.manager_id = ANY(m.path)
+		)
+		SELECT EXISTS (
+			SELECT 1
+			FROM Subscriptions s
+			JOIN Employees e ON e.id = $2 AND e.archived_at IS NULL
+			WHERE s.user_id = $1
+			  AND (s.emp_id = $2
+			   OR s.all_employees
+			   OR s.team_id IN (SELECT team_id FROM TeamMembers WHERE emp_id = $2)
+			   OR EXISTS (SELECT 1 FROM managers m WHERE m.id = s.reports_of AND (s.depth = 0 OR m.level <= s.depth))))`,
+		userID, employeeID).Scan(&subscribed)
+	if err != nil {
+		s.log.Error("failed to check subscription", errMsg.Err(err))
+		return false, err
+	}
+	return subscribed, nil
+}
+
+func (s *SubsRepository) StreamUserSubs(ctx context.Context, userID int, fn func(entities.SubscriptionDetails) error) error {
+	rows, err := s.db.Query(ctx, `SELECT s.id, COALESCE(e.id, 0), COALESCE(e.name, ''), e.birthday, COALESCE(e.birthday_visibility, ''),
+			COALESCE(t.id, 0), COALESCE(t.name, ''), COALESCE(s.reports_of, 0), s.depth, s.all_employees, s.muted_until,
+			s.shift_to_business_day, s.event_types
+		FROM Subscriptions s
+		LEFT JOIN Employees e ON e.id = s.emp_id
+		LEFT JOIN Teams t ON t.id = s.team_id
+		WHERE s.user_id = $1 AND (e.id IS NULL OR e.archived_at IS NULL)
+		ORDER BY s.id`, userID)
+	if err != nil {
+		s.log.Error("failed to get user subscriptions", errMsg.Err(err))
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sub entities.SubscriptionDetails
+		if err := rows.Scan(&sub.ID, &sub.EmployeeID, &sub.EmployeeName, &sub.Birthday, &sub.Visibility,
+			&sub.TeamID, &sub.TeamName, &sub.ReportsOf, &sub.Depth, &sub.AllEmployees, &sub.MutedUntil,
+			&sub.ShiftToBusinessDay, &sub.EventTypes); err != nil {
+			s.log.Error("failed to scan subscription", errMsg.Err(err))
+			return err
+		}
+		if err := fn(sub); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
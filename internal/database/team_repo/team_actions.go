@@ -0,0 +1,143 @@
+package database
+
+import (
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TeamRepository struct {
+	db  *pgxpool.Pool
+	log *slog.Logger
+}
+
+func NewTeamRepository(db *pgxpool.Pool, log *slog.Logger) *TeamRepository {
+	return &TeamRepository{db, log}
+}
+
+func (t *TeamRepository) CreateDepartment(ctx context.Context, department *entities.Department) error {
+	err := t.db.QueryRow(ctx, `INSERT INTO Departments (name) VALUES ($1) RETURNING id`, department.Name).Scan(&department.ID)
+	if err != nil {
+		t.log.Error("failed to create department", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (t *TeamRepository) GetAllDepartments(ctx context.Context) ([]entities.Department, error) {
+	rows, err := t.db.Query(ctx, `SELECT id, name FROM Departments ORDER BY name`)
+	if err != nil {
+		t.log.Error("failed to get departments", errMsg.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var departments []entities.Department
+	for rows.Next() {
+		var department entities.Department
+		if err := rows.Scan(&department.ID, &department.Name); err != nil {
+			t.log.Error("failed to scan department", errMsg.Err(err))
+			return nil, err
+		}
+		departments = append(departments, department)
+	}
+	return departments, rows.Err()
+}
+
+func (t *TeamRepository) DeleteDepartment(ctx context.Context, id int) error {
+	_, err := t.db.Exec(ctx, `DELETE FROM Departments WHERE id = $1`, id)
+	if err != nil {
+		t.log.Error("failed to delete department", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (t *TeamRepository) CreateTeam(ctx context.Context, team *entities.Team) error {
+	err := t.db.QueryRow(ctx, `INSERT INTO Teams (name, department_id) VALUES ($1, NULLIF($2, 0)) RETURNING id`,
+		team.Name, team.DepartmentID).Scan(&team.ID)
+	if err != nil {
+		t.log.Error("failed to create team", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (t *TeamRepository) GetAllTeams(ctx context.Context, departmentID int) ([]entities.Team, error) {
+	rows, err := t.db.Query(ctx, `SELECT id, name, COALESCE(department_id, 0)
+		FROM Teams
+		WHERE $1 = 0 OR department_id = $1
+		ORDER BY name`, departmentID)
+	if err != nil {
+		t.log.Error("failed to get teams", errMsg.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teams []entities.Team
+	for rows.Next() {
+		var team entities.Team
+		if err := rows.Scan(&team.ID, &team.Name, &team.DepartmentID); err != nil {
+			t.log.Error("failed to scan team", errMsg.Err(err))
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+	return teams, rows.Err()
+}
+
+func (t *TeamRepository) DeleteTeam(ctx context.Context, id int) error {
+	_, err := t.db.Exec(ctx, `DELETE FROM Teams WHERE id = $1`, id)
+	if err != nil {
+		t.log.Error("failed to delete team", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (t *TeamRepository) AddMember(ctx context.Context, teamID, empID int) error {
+	_, err := t.db.Exec(ctx, `INSERT INTO TeamMembers (team_id, emp_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, teamID, empID)
+	if err != nil {
+		t.log.Error("failed to add team member", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (t *TeamRepository) RemoveMember(ctx context.Context, teamID, empID int) error {
+	_, err := t.db.Exec(ctx, `DELETE FROM TeamMembers WHERE team_id = $1 AND emp_id = $2`, teamID, empID)
+	if err != nil {
+		t.log.Error("failed to remove team member", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (t *TeamRepository) GetMembers(ctx context.Context, teamID int) ([]entities.Employee, error) {
+	rows, err := t.db.Query(ctx, `SELECT e.id, e.name, e.birthday, COALESCE(e.external_id, ''), COALESCE(e.manager_id, 0), COALESCE(e.email, ''),
+			e.birthday_visibility, e.notifications_opt_out
+		FROM Employees e
+		JOIN TeamMembers m ON m.emp_id = e.id
+		WHERE m.team_id = $1 AND e.archived_at IS NULL
+		ORDER BY e.name`, teamID)
+	if err != nil {
+		t.log.Error("failed to get team members", errMsg.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var employees []entities.Employee
+	for rows.Next() {
+		var employee entities.Employee
+		if err := rows.Scan(&employee.ID, &employee.Name, &employee.Birthday, &employee.ExternalID, &employee.ManagerID,
+			&employee.Email, &employee.Visibility, &employee.OptOut); err != nil {
+			t.log.Error("failed to scan team member", errMsg.Err(err))
+			return nil, err
+		}
+		employees = append(employees, employee)
+	}
+	return employees, rows.Err()
+}
@@ -6,10 +6,14 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const userColumns = `id, email, password, COALESCE(employee_id, 0), is_admin, digest_mode, unsubscribed_all,
+	muted_until, quiet_hours_start, quiet_hours_end, timezone, delivery_hour`
+
 type UserRepository struct {
 	db  *pgxpool.Pool
 	log *slog.Logger
@@ -20,7 +24,8 @@ func NewUserRepository(db *pgxpool.Pool, log *slog.Logger) *UserRepository {
 }
 
 func (u *UserRepository) CreateUser(ctx context.Context, user *entities.User) error {
-	err := u.db.QueryRow(ctx, `INSERT INTO Users (email, password) VALUES ($1, $2) RETURNING id`, user.Email, user.Password).Scan(&user.ID)
+	err := u.db.QueryRow(ctx, `INSERT INTO Users (email, password, is_admin)
+		VALUES ($1, $2, $3) RETURNING id`, user.Email, user.Password, user.IsAdmin).Scan(&user.ID)
 	if err != nil {
 		u.log.Error("Failed to create user", errMsg.Err(err))
 		return err
@@ -29,7 +34,7 @@ func (u *UserRepository) CreateUser(ctx context.Context, user *entities.User) er
 }
 
 func (u *UserRepository) FindUserByEmail(ctx context.Context, email string) (entities.User, error) {
-	query, err := u.db.Query(ctx, `SELECT id, email, password FROM Users WHERE email = $1`, email)
+	query, err := u.db.Query(ctx, `SELECT `+userColumns+` FROM Users WHERE email = $1`, email)
 	if err != nil {
 		u.log.Error("Error querying users table", errMsg.Err(err))
 		return entities.User{}, err
@@ -40,7 +45,8 @@ func (u *UserRepository) FindUserByEmail(ctx context.Context, email string) (ent
 		u.log.Error("user not found")
 		return entities.User{}, fmt.Errorf("user not found")
 	} else {
-		err := query.Scan(&row.ID, &row.Email, &row.Password)
+		err := query.Scan(&row.ID, &row.Email, &row.Password, &row.EmployeeID, &row.IsAdmin, &row.DigestMode, &row.UnsubscribedAll,
+			&row.MutedUntil, &row.QuietHoursStart, &row.QuietHoursEnd, &row.Timezone, &row.DeliveryHour)
 		if err != nil {
 			u.log.Error("Error scanning users", errMsg.Err(err))
 			return entities.User{}, err
@@ -50,7 +56,7 @@ func (u *UserRepository) FindUserByEmail(ctx context.Context, email string) (ent
 }
 
 func (u *UserRepository) FindUserById(ctx context.Context, id int) (entities.User, error) {
-	query, err := u.db.Query(ctx, `SELECT id, email, password FROM Users WHERE id = $1`, id)
+	query, err := u.db.Query(ctx, `SELECT `+userColumns+` FROM Users WHERE id = $1`, id)
 	if err != nil {
 		u.log.Error("error querying users", errMsg.Err(err))
 		return entities.User{}, err
@@ -61,7 +67,8 @@ func (u *UserRepository) FindUserById(ctx context.Context, id int) (entities.Use
 		u.log.Error("user not found")
 		return entities.User{}, fmt.Errorf("user not found")
 	} else {
-		err := query.Scan(&rowArray.ID, &rowArray.Email, &rowArray.Password)
+		err := query.Scan(&rowArray.ID, &rowArray.Email, &rowArray.Password, &rowArray.EmployeeID, &rowArray.IsAdmin, &rowArray.DigestMode, &rowArray.UnsubscribedAll,
+			&rowArray.MutedUntil, &rowArray.QuietHoursStart, &rowArray.QuietHoursEnd, &rowArray.Timezone, &rowArray.DeliveryHour)
 		if err != nil {
 			u.log.Error("error scanning users", errMsg.Err(err))
 			return entities.User{}, err
@@ -89,3 +96,65 @@ func (u *UserRepository) UpdateUser(ctx context.Context, user *entities.User) er
 	return nil
 
 }
+
+func (u *UserRepository) LinkEmployee(ctx context.Context, userID, employeeID int) error {
+	_, err := u.db.Exec(ctx, `UPDATE Users SET employee_id = NULLIF($2, 0) WHERE id = $1`, userID, employeeID)
+	if err != nil {
+		u.log.Error("failed to link employee", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (u *UserRepository) LinkEmployeesByEmail(ctx context.Context) (int, error) {
+	tag, err := u.db.Exec(ctx, `UPDATE Users u SET employee_id = e.id
+		FROM Employees e
+		WHERE u.employee_id IS NULL
+		  AND lower(e.email) = lower(u.email)
+		  AND NOT EXISTS (SELECT 1 FROM Users linked WHERE linked.employee_id = e.id)`)
+	if err != nil {
+		u.log.Error("failed to link employees by email", errMsg.Err(err))
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+func (u *UserRepository) UpdateNotificationSettings(ctx context.Context, user *entities.User) error {
+	_, err := u.db.Exec(ctx, `UPDATE Users SET digest_mode = $2, unsubscribed_all = $3, timezone = $4, delivery_hour = $5
+		WHERE id = $1`, user.ID, user.DigestMode, user.UnsubscribedAll, user.Timezone, user.DeliveryHour)
+	if err != nil {
+		u.log.Error("failed to update notification settings", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (u *UserRepository) SetUnsubscribedAll(ctx context.Context, userID int, unsubscribed bool) (bool, error) {
+	tag, err := u.db.Exec(ctx, `UPDATE Users SET unsubscribed_all = $2 WHERE id = $1`, userID, unsubscribed)
+	if err != nil {
+		u.log.Error("failed to update unsubscribe flag", errMsg.Err(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// SetMutedUntil pauses all notifications for the user until the given time; nil unmutes.
+func (u *UserRepository) SetMutedUntil(ctx context.Context, userID int, until *time.Time) error {
+	_, err := u.db.Exec(ctx, `UPDATE Users SET muted_until = $2 WHERE id = $1`, userID, until)
+	if err != nil {
+		u.log.Error("failed to update mute", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+// SetQuietHours sets the user's quiet hours window; nil bounds clear it.
+func (u *UserRepository) SetQuietHours(ctx context.Context, userID int, start, end *int) error {
+	_, err := u.db.Exec(ctx, `UPDATE Users SET quiet_hours_start = $2, quiet_hours_end = $3 WHERE id = $1`,
+		userID, start, end)
+	if err != nil {
+		u.log.Error("failed to update quiet hours", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
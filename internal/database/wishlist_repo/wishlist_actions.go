@@ -0,0 +1,107 @@
+package database
+
+import (
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WishlistRepository struct {
+	db  *pgxpool.Pool
+	log *slog.Logger
+}
+
+func NewWishlistRepository(db *pgxpool.Pool, log *slog.Logger) *WishlistRepository {
+	return &WishlistRepository{db, log}
+}
+
+const wishlistColumns = `id, emp_id, title, url, price_min, price_max, COALESCE(reserved_by, 0), created_at`
+
+func scanItem(row pgx.Row, item *entities.WishlistItem) error {
+	return row.Scan(&item.ID, &item.EmployeeID, &item.Title, &item.URL, &item.PriceMin, &item.PriceMax,
+		&item.ReservedBy, &item.CreatedAt)
+}
+
+func (w *WishlistRepository) CreateItem(ctx context.Context, item *entities.WishlistItem) error {
+	err := w.db.QueryRow(ctx, `INSERT INTO WishlistItems (emp_id, title, url, price_min, price_max)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at`,
+		item.EmployeeID, item.Title, item.URL, item.PriceMin, item.PriceMax).Scan(&item.ID, &item.CreatedAt)
+	if err != nil {
+		w.log.Error("failed to create wishlist item", errMsg.Err(err))
+		return err
+	}
+	return nil
+}
+
+// UpdateItem changes the item's description; reservations are kept.
+func (w *WishlistRepository) UpdateItem(ctx context.Context, item *entities.WishlistItem) (bool, error) {
+	err := scanItem(w.db.QueryRow(ctx, `UPDATE WishlistItems SET title = $3, url = $4, price_min = $5, price_max = $6
+		WHERE emp_id = $1 AND id = $2
+		RETURNING `+wishlistColumns,
+		item.EmployeeID, item.ID, item.Title, item.URL, item.PriceMin, item.PriceMax), item)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		w.log.Error("failed to update wishlist item", errMsg.Err(err))
+		return false, err
+	}
+	return true, nil
+}
+
+func (w *WishlistRepository) DeleteItem(ctx context.Context, empID, id int) (bool, error) {
+	tag, err := w.db.Exec(ctx, `DELETE FROM WishlistItems WHERE emp_id = $1 AND id = $2`, empID, id)
+	if err != nil {
+		w.log.Error("failed to delete wishlist item", errMsg.Err(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (w *WishlistRepository) GetWishlist(ctx context.Context, empID int) ([]entities.WishlistItem, error) {
+	rows, err := w.db.Query(ctx, `SELECT `+wishlistColumns+` FROM WishlistItems WHERE emp_id = $1 ORDER BY id`, empID)
+	if err != nil {
+		w.log.Error("failed to get wishlist", errMsg.Err(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []entities.WishlistItem
+	for rows.Next() {
+		var item entities.WishlistItem
+		if err := scanItem(rows, &item); err != nil {
+			w.log.Error("failed to scan wishlist item", errMsg.Err(err))
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// ReserveItem reserves the item for userID; it returns false when the item does not exist or
+// someone else has already reserved it.
+func (w *WishlistRepository) ReserveItem(ctx context.Context, empID, id, userID int) (bool, error) {
+	tag, err := w.db.Exec(ctx, `UPDATE WishlistItems SET reserved_by = $3, reserved_at = NOW()
+		WHERE emp_id = $1 AND id = $2 AND (reserved_by IS NULL OR reserved_by = $3)`, empID, id, userID)
+	if err != nil {
+		w.log.Error("failed to reserve wishlist item", errMsg.Err(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// CancelReservation releases the item if userID reserved it.
+func (w *WishlistRepository) CancelReservation(ctx context.Context, empID, id, userID int) (bool, error) {
+	tag, err := w.db.Exec(ctx, `UPDATE WishlistItems SET reserved_by = NULL, reserved_at = NULL
+		WHERE emp_id = $1 AND id = $2 AND reserved_by = $3`, empID, id, userID)
+	if err != nil {
+		w.log.Error("failed to cancel wishlist reservation", errMsg.Err(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
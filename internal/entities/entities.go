@@ -1,22 +1,263 @@
 package entities
 
-import "time"
+import (
+	"time"
+)
 
 type User struct {
-	ID        int       `json:"user_id"`
-	CreatedAt time.Time `json:"created_at"`
-	Email     string    `json:"email"`
-	Password  string    `json:"password"`
+	ID         int       `json:"user_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	Email      string    `json:"email"`
+	Password   string    `json:"password"`
+	EmployeeID int       `json:"employee_id,omitempty"`
+	IsAdmin    bool      `json:"is_admin"`
+	DigestMode string    `json:"digest_mode,omitempty"`
+	// UnsubscribedAll is set when the user opted out of every notification.
+	UnsubscribedAll bool       `json:"unsubscribed_all"`
+	MutedUntil      *time.Time `json:"muted_until,omitempty"`
+	// QuietHoursStart and QuietHoursEnd delimit the hours [start, end) when no mail is sent.
+	QuietHoursStart *int `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd   *int `json:"quiet_hours_end,omitempty"`
+	// Timezone is an IANA name; quiet hours, the delivery hour and "today" are local to it.
+	Timezone     string `json:"timezone,omitempty"`
+	DeliveryHour *int   `json:"delivery_hour,omitempty"`
 }
 
+const (
+	DigestImmediate = "immediate"
+	DigestDaily     = "daily"
+	DigestWeekly    = "weekly"
+)
+
 type Subscription struct {
 	ID         int
 	UserID     int
 	EmployeeID int
+	TeamID     int
+	ReportsOf  int
+	Depth      int
+	// AllEmployees subscribes the user to every active employee.
+	AllEmployees bool
+	// ShiftToBusinessDay moves reminders for days off to the preceding business day.
+	ShiftToBusinessDay bool
+	// EventTypes lists the event types the subscription notifies about.
+	EventTypes []string
+}
+
+type SubscriptionDetails struct {
+	ID                 int        `json:"id"`
+	EmployeeID         int        `json:"emp_id,omitempty"`
+	EmployeeName       string     `json:"name,omitempty"`
+	Birthday           *time.Time `json:"birthday,omitempty"`
+	DayMonth           string     `json:"birthday_day_month,omitempty"`
+	Visibility         string     `json:"-"`
+	TeamID             int        `json:"team_id,omitempty"`
+	TeamName           string     `json:"team_name,omitempty"`
+	ReportsOf          int        `json:"reports_of,omitempty"`
+	Depth              int        `json:"depth,omitempty"`
+	AllEmployees       bool       `json:"all_employees,omitempty"`
+	MutedUntil         *time.Time `json:"muted_until,omitempty"`
+	ShiftToBusinessDay bool       `json:"shift_to_business_day,omitempty"`
+	EventTypes         []string   `json:"event_types"`
 }
 
 type Employee struct {
-	ID       int       `json:"employee_id"`
-	Name     string    `json:"name"`
-	Birthday time.Time `json:"birthday"`
+	ID         int        `json:"employee_id"`
+	Name       string     `json:"name"`
+	Birthday   time.Time  `json:"birthday"`
+	ExternalID string     `json:"external_id,omitempty"`
+	ManagerID  int        `json:"manager_id,omitempty"`
+	Email      string     `json:"email,omitempty"`
+	Visibility string     `json:"birthday_visibility,omitempty"`
+	OptOut     bool       `json:"notifications_opt_out,omitempty"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+}
+
+const (
+	EventBirthday        = "birthday"
+	EventWorkAnniversary = "work_anniversary"
+	EventCustom          = "custom"
+)
+
+const (
+	RecurrenceYearly = "yearly"
+	RecurrenceOnce   = "once"
+)
+
+// Event is a date celebrated for an employee. Birthdays come from Employee.Birthday and have no ID.
+type Event struct {
+	ID         int       `json:"id,omitempty"`
+	EmployeeID int       `json:"emp_id"`
+	Type       string    `json:"type"`
+	Name       string    `json:"name,omitempty"`
+	Date       time.Time `json:"date"`
+	Recurrence string    `json:"recurrence"`
+}
+
+// UpcomingBirthday is an employee's next birthday and the age reached on it.
+type UpcomingBirthday struct {
+	Employee
+	Date time.Time `json:"date"`
+	Age  int       `json:"age"`
+}
+
+type UpcomingEvent struct {
+	Employee
+	Event       Event        `json:"event"`
+	HasWishlist bool         `json:"has_wishlist"`
+	Subscribers []Subscriber `json:"subscribers"`
+}
+
+// Subscriber is a user notified about an employee through the subscription SubscriptionID.
+type Subscriber struct {
+	User
+	SubscriptionID     int  `json:"subscription_id"`
+	ShiftToBusinessDay bool `json:"shift_to_business_day"`
+	// EventCovered is set when at least one of the subscriptions includes the event type.
+	EventCovered bool `json:"event_covered"`
+	// SubscriptionMuted is set when every subscription covering the event is muted.
+	SubscriptionMuted bool `json:"subscription_muted"`
+}
+
+// Holiday overrides the weekly calendar for one day: a public holiday, or a working
+// weekend day when Working is set.
+type Holiday struct {
+	Date    time.Time `json:"date"`
+	Name    string    `json:"name,omitempty"`
+	Working bool      `json:"working"`
+}
+
+type OrgNode struct {
+	Employee
+	Level int `json:"level"`
+}
+
+type Department struct {
+	ID   int    `json:"department_id"`
+	Name string `json:"name"`
+}
+
+type Team struct {
+	ID           int    `json:"team_id"`
+	Name         string `json:"name"`
+	DepartmentID int    `json:"department_id,omitempty"`
+}
+
+type OutboxMessage struct {
+	ID            int64             `json:"id"`
+	EmployeeID    int               `json:"emp_id,omitempty"`
+	Recipient     string            `json:"recipient"`
+	Subject       string            `json:"subject"`
+	Body          string            `json:"body"`
+	Headers       map[string]string `json:"headers,omitempty"`
+	DedupKey      string            `json:"dedup_key"`
+	Status        string            `json:"status"`
+	Attempts      int               `json:"attempts"`
+	LastError     string            `json:"last_error,omitempty"`
+	NextAttemptAt time.Time         `json:"next_attempt_at"`
+	CreatedAt     time.Time         `json:"created_at"`
+	SentAt        *time.Time        `json:"sent_at,omitempty"`
+	Deliveries    []Delivery        `json:"-"`
+}
+
+// Delivery records that recipient was told about one occurrence of an event, e.g. a birthday in a given year.
+type Delivery struct {
+	Recipient  string    `json:"recipient"`
+	EventKey   string    `json:"event_key"`
+	Occurrence time.Time `json:"occurrence"`
+}
+
+const (
+	AttemptQueued  = "queued"
+	AttemptSent    = "sent"
+	AttemptFailed  = "failed"
+	AttemptBounced = "bounced"
+	AttemptSkipped = "skipped"
+)
+
+// NotificationAttempt is one entry of the notification history: a message queued, a delivery
+// attempt and its outcome, or a notification skipped on purpose.
+type NotificationAttempt struct {
+	ID               int64     `json:"id"`
+	OutboxID         *int64    `json:"outbox_id,omitempty"`
+	EmployeeID       int       `json:"emp_id,omitempty"`
+	EmployeeName     string    `json:"name,omitempty"`
+	Recipient        string    `json:"recipient"`
+	Subject          string    `json:"subject,omitempty"`
+	Status           string    `json:"status"`
+	Reason           string    `json:"reason,omitempty"`
+	ProviderResponse string    `json:"provider_response,omitempty"`
+	DedupKey         string    `json:"-"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// AttemptFilter narrows a notification history search; zero values match everything.
+type AttemptFilter struct {
+	EmployeeID int
+	Recipient  string
+	Status     string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+}
+
+// Collection pools money for an employee's birthday. Amounts are whole currency units.
+type Collection struct {
+	ID           int        `json:"id"`
+	EmployeeID   int        `json:"emp_id"`
+	EmployeeName string     `json:"name"`
+	Occurrence   time.Time  `json:"occurrence"`
+	OrganiserID  int        `json:"organiser_id"`
+	Title        string     `json:"title,omitempty"`
+	Target       int        `json:"target,omitempty"`
+	Pledged      int        `json:"pledged"`
+	Paid         int        `json:"paid"`
+	Pledgers     int        `json:"pledgers"`
+	ClosedAt     *time.Time `json:"closed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type Pledge struct {
+	UserID    int        `json:"user_id"`
+	Email     string     `json:"email,omitempty"`
+	Amount    int        `json:"amount"`
+	PaidAt    *time.Time `json:"paid_at,omitempty"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// BoardMessage is a colleague's greeting on an employee's card for the birthday in Year.
+type BoardMessage struct {
+	ID          int       `json:"id"`
+	EmployeeID  int       `json:"emp_id"`
+	Year        int       `json:"year"`
+	AuthorID    int       `json:"author_id"`
+	AuthorEmail string    `json:"author_email"`
+	Body        string    `json:"body"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Card collects the board messages to send to the birthday person. Recipient is the linked user,
+// or carries just the employee's email when no user is linked.
+type Card struct {
+	Employee   Employee
+	Year       int
+	Occurrence time.Time
+	Recipient  User
+	Messages   []BoardMessage
+}
+
+// WishlistItem is a gift idea on an employee's wishlist. Prices are whole currency units.
+// Who reserved an item is never shown; the birthday person does not see reservations at all.
+type WishlistItem struct {
+	ID           int       `json:"id"`
+	EmployeeID   int       `json:"emp_id"`
+	Title        string    `json:"title"`
+	URL          string    `json:"url,omitempty"`
+	PriceMin     *int      `json:"price_min,omitempty"`
+	PriceMax     *int      `json:"price_max,omitempty"`
+	ReservedBy   int       `json:"-"`
+	Reserved     bool      `json:"reserved,omitempty"`
+	ReservedByMe bool      `json:"reserved_by_me,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
 }
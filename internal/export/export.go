@@ -0,0 +1,206 @@
+package export
+
+import (
+	"birthday-service/internal/entities"
+	"birthday-service/internal/privacy"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatJSON  = "json"
+	FormatVCard = "vcf"
+
+	dateLayout  = "2006-01-02"
+	vCardNoYear = "--0102"
+)
+
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatVCard:
+		return "text/vcard; charset=utf-8"
+	default:
+		return "application/json"
+	}
+}
+
+func Supported(format string) bool {
+	return format == FormatCSV || format == FormatJSON || format == FormatVCard
+}
+
+// EmployeeWriter streams employees in one format; birthdays are redacted according
+// to each employee's visibility and emails are left out unless admin is set.
+type EmployeeWriter struct {
+	format string
+	admin  bool
+	w      io.Writer
+	csv    *csv.Writer
+	count  int
+}
+
+func NewEmployeeWriter(w io.Writer, format string, admin bool) (*EmployeeWriter, error) {
+	if !Supported(format) {
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+	ew := &EmployeeWriter{format: format, admin: admin, w: w}
+	switch format {
+	case FormatCSV:
+		ew.csv = csv.NewWriter(w)
+		if err := ew.csv.Write([]string{"employee_id", "name", "birthday", "external_id", "email"}); err != nil {
+			return nil, err
+		}
+	case FormatJSON:
+		if _, err := io.WriteString(w, "["); err != nil {
+			return nil, err
+		}
+	}
+	return ew, nil
+}
+
+func (ew *EmployeeWriter) Write(employee entities.Employee) error {
+	defer func() { ew.count++ }()
+	switch ew.format {
+	case FormatCSV:
+		return ew.csv.Write([]string{
+			strconv.Itoa(employee.ID),
+			employee.Name,
+			privacy.FormatBirthday(employee, ew.admin, dateLayout, privacy.DayMonthLayout),
+			employee.ExternalID,
+			privacy.Email(employee, ew.admin),
+		})
+	case FormatVCard:
+		_, err := io.WriteString(ew.w, vCard(employee, ew.admin))
+		return err
+	default:
+		if ew.count > 0 {
+			if _, err := io.WriteString(ew.w, ","); err != nil {
+				return err
+			}
+		}
+		return json.NewEncoder(ew.w).Encode(privacy.View(employee, ew.admin))
+	}
+}
+
+func (ew *EmployeeWriter) Close() error {
+	switch ew.format {
+	case FormatCSV:
+		ew.csv.Flush()
+		return ew.csv.Error()
+	case FormatJSON:
+		_, err := io.WriteString(ew.w, "]\n")
+		return err
+	}
+	return nil
+}
+
+type SubscriptionWriter struct {
+	format    string
+	admin     bool
+	w         io.Writer
+	csv       *csv.Writer
+	employees *EmployeeWriter
+	count     int
+}
+
+func NewSubscriptionWriter(w io.Writer, format string, admin bool) (*SubscriptionWriter, error) {
+	if !Supported(format) {
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+	sw := &SubscriptionWriter{format: format, admin: admin, w: w}
+	switch format {
+	case FormatCSV:
+		sw.csv = csv.NewWriter(w)
+		if err := sw.csv.Write([]string{"subscription_id", "employee_id", "name", "birthday", "team_id", "team_name", "reports_of", "depth", "all_employees"}); err != nil {
+			return nil, err
+		}
+	case FormatJSON:
+		if _, err := io.WriteString(w, "["); err != nil {
+			return nil, err
+		}
+	case FormatVCard:
+		sw.employees = &EmployeeWriter{format: format, admin: admin, w: w}
+	}
+	return sw, nil
+}
+
+func (sw *SubscriptionWriter) Write(sub entities.SubscriptionDetails) error {
+	defer func() { sw.count++ }()
+	if sw.format != FormatVCard {
+		sub = privacy.SubscriptionView(sub, sw.admin)
+	}
+	switch sw.format {
+	case FormatCSV:
+		record := []string{strconv.Itoa(sub.ID), "", sub.EmployeeName, "", "", sub.TeamName, "", "",
+			strconv.FormatBool(sub.AllEmployees)}
+		if sub.EmployeeID != 0 {
+			record[1] = strconv.Itoa(sub.EmployeeID)
+		}
+		if sub.Birthday != nil {
+			record[3] = sub.Birthday.Format(dateLayout)
+		} else {
+			record[3] = sub.DayMonth
+		}
+		if sub.TeamID != 0 {
+			record[4] = strconv.Itoa(sub.TeamID)
+		}
+		if sub.ReportsOf != 0 {
+			record[6] = strconv.Itoa(sub.ReportsOf)
+			record[7] = strconv.Itoa(sub.Depth)
+		}
+		return sw.csv.Write(record)
+	case FormatVCard:
+		if sub.Birthday == nil {
+			return nil
+		}
+		return sw.employees.Write(entities.Employee{ID: sub.EmployeeID, Name: sub.EmployeeName,
+			Birthday: *sub.Birthday, Visibility: sub.Visibility})
+	default:
+		if sw.count > 0 {
+			if _, err := io.WriteString(sw.w, ","); err != nil {
+				return err
+			}
+		}
+		return json.NewEncoder(sw.w).Encode(sub)
+	}
+}
+
+func (sw *SubscriptionWriter) Close() error {
+	switch sw.format {
+	case FormatCSV:
+		sw.csv.Flush()
+		return sw.csv.Error()
+	case FormatJSON:
+		_, err := io.WriteString(sw.w, "]\n")
+		return err
+	}
+	return nil
+}
+
+func vCard(employee entities.Employee, admin bool) string {
+	name := escapeVCard(employee.Name)
+	var b strings.Builder
+	b.WriteString("BEGIN:VCARD\r\n")
+	b.WriteString("VERSION:3.0\r\n")
+	b.WriteString("FN:" + name + "\r\n")
+	b.WriteString("N:;" + name + ";;;\r\n")
+	if bday := privacy.FormatBirthday(employee, admin, dateLayout, vCardNoYear); bday != "" {
+		b.WriteString("BDAY:" + bday + "\r\n")
+	}
+	if email := privacy.Email(employee, admin); email != "" {
+		b.WriteString("EMAIL;TYPE=INTERNET:" + escapeVCard(email) + "\r\n")
+	}
+	b.WriteString(fmt.Sprintf("UID:urn:birthday-service:employee:%d\r\n", employee.ID))
+	b.WriteString("END:VCARD\r\n")
+	return b.String()
+}
+
+func escapeVCard(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
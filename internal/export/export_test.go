@@ -0,0 +1,169 @@
+package export
+
+import (
+	"birthday-service/internal/entities"
+	"birthday-service/internal/privacy"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+var employees = []entities.Employee{
+	{ID: 1, Name: "Anna", Birthday: time.Date(1990, time.March, 14, 0, 0, 0, 0, time.UTC),
+		Email: "anna@example.com", ExternalID: "A1"},
+	{ID: 2, Name: "Smith; Boris", Birthday: time.Date(1985, time.April, 5, 0, 0, 0, 0, time.UTC),
+		Email: "boris@example.com", Visibility: privacy.VisibilityDayMonth},
+	{ID: 3, Name: "Vera", Birthday: time.Date(1979, time.July, 1, 0, 0, 0, 0, time.UTC),
+		Email: "vera@example.com", Visibility: privacy.VisibilityHidden},
+}
+
+func writeEmployees(t *testing.T, format string, admin bool) string {
+	t.Helper()
+	var buf bytes.Buffer
+	writer, err := NewEmployeeWriter(&buf, format, admin)
+	if err != nil {
+		t.Fatalf("NewEmployeeWriter: %v", err)
+	}
+	for _, employee := range employees {
+		if err := writer.Write(employee); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return buf.String()
+}
+
+func TestEmployeeWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		admin    bool
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "csv",
+			format:   FormatCSV,
+			contains: []string{"employee_id,name,birthday,external_id,email\n", "1,Anna,1990-03-14,A1,\n", "2,Smith; Boris,--04-05,,\n", "3,Vera,,,\n"},
+			excludes: []string{"@example.com"},
+		},
+		{
+			name:     "csv for admins",
+			format:   FormatCSV,
+			admin:    true,
+			contains: []string{"1,Anna,1990-03-14,A1,anna@example.com\n", "3,Vera,1979-07-01,,vera@example.com\n"},
+		},
+		{
+			name:     "vcard",
+			format:   FormatVCard,
+			contains: []string{"FN:Smith\\; Boris\r\n", "BDAY:1990-03-14\r\n", "BDAY:--0405\r\n", "UID:urn:birthday-service:employee:3\r\n"},
+			excludes: []string{"EMAIL", "1979"},
+		},
+		{
+			name:     "vcard for admins",
+			format:   FormatVCard,
+			admin:    true,
+			contains: []string{"EMAIL;TYPE=INTERNET:vera@example.com\r\n", "BDAY:1979-07-01\r\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := writeEmployees(t, tt.format, tt.admin)
+			for _, want := range tt.contains {
+				if !strings.Contains(out, want) {
+					t.Errorf("output lacks %q:\n%s", want, out)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(out, unwanted) {
+					t.Errorf("output contains %q:\n%s", unwanted, out)
+				}
+			}
+		})
+	}
+}
+
+func TestEmployeeWriterJSON(t *testing.T) {
+	var views []privacy.EmployeeView
+	if err := json.Unmarshal([]byte(writeEmployees(t, FormatJSON, false)), &views); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(views) != len(employees) {
+		t.Fatalf("got %d employees, want %d", len(views), len(employees))
+	}
+	if views[0].Birthday == nil || views[1].BirthdayDayMonth != "--04-05" || views[2].Birthday != nil || views[2].BirthdayDayMonth != "" {
+		t.Fatalf("birthdays are not redacted: %+v", views)
+	}
+	for _, view := range views {
+		if view.Email != "" {
+			t.Fatalf("email leaked: %+v", view)
+		}
+	}
+}
+
+func TestEmptyJSONExport(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewEmployeeWriter(&buf, FormatJSON, false)
+	if err != nil {
+		t.Fatalf("NewEmployeeWriter: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "[]" {
+		t.Fatalf("empty export = %q, want []", got)
+	}
+}
+
+func TestSubscriptionWriter(t *testing.T) {
+	birthday := time.Date(1985, time.April, 5, 0, 0, 0, 0, time.UTC)
+	subs := []entities.SubscriptionDetails{
+		{ID: 1, EmployeeID: 2, EmployeeName: "Boris", Birthday: &birthday, Visibility: privacy.VisibilityDayMonth},
+		{ID: 2, TeamID: 4, TeamName: "Platform"},
+		{ID: 3, ReportsOf: 5, Depth: 2},
+		{ID: 4, AllEmployees: true},
+	}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: FormatCSV, want: "subscription_id,employee_id,name,birthday,team_id,team_name,reports_of,depth,all_employees\n" +
+			"1,2,Boris,--04-05,,,,,false\n" +
+			"2,,,,4,Platform,,,false\n" +
+			"3,,,,,,5,2,false\n" +
+			"4,,,,,,,,true\n"},
+		{format: FormatVCard, want: "BEGIN:VCARD\r\nVERSION:3.0\r\nFN:Boris\r\nN:;Boris;;;\r\nBDAY:--0405\r\n" +
+			"UID:urn:birthday-service:employee:2\r\nEND:VCARD\r\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		writer, err := NewSubscriptionWriter(&buf, tt.format, false)
+		if err != nil {
+			t.Fatalf("NewSubscriptionWriter: %v", err)
+		}
+		for _, sub := range subs {
+			if err := writer.Write(sub); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("%s export = %q, want %q", tt.format, buf.String(), tt.want)
+		}
+	}
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	if _, err := NewEmployeeWriter(&bytes.Buffer{}, "xml", false); err == nil {
+		t.Fatal("expected an error for an unsupported format")
+	}
+	if _, err := NewSubscriptionWriter(&bytes.Buffer{}, "xml", false); err == nil {
+		t.Fatal("expected an error for an unsupported format")
+	}
+}
@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/internal/notification"
+	"birthday-service/internal/privacy"
+	"birthday-service/internal/schedule"
+	"birthday-service/jwt"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+)
+
+type Board interface {
+	SaveMessage(ctx context.Context, message *entities.BoardMessage) error
+	GetMessages(ctx context.Context, empID, year int) ([]entities.BoardMessage, error)
+	DeleteMessage(ctx context.Context, empID, year, authorID int) (bool, error)
+}
+
+type Employees interface {
+	FindEmployeeById(ctx context.Context, id int) (entities.Employee, error)
+}
+
+type Subscribers interface {
+	IsSubscribed(ctx context.Context, userID, employeeID int) (bool, error)
+}
+
+type RequestMessage struct {
+	Body string `json:"body" validate:"required,max=2000"`
+}
+
+type ResponseMessage struct {
+	response.Response
+	Message entities.BoardMessage `json:"message"`
+}
+
+type ResponseBoard struct {
+	response.Response
+	EmployeeID int                     `json:"emp_id"`
+	Name       string                  `json:"name"`
+	Occurrence time.Time               `json:"occurrence"`
+	Messages   []entities.BoardMessage `json:"messages"`
+}
+
+// board is the greeting card for one birthday as seen by the current user.
+type board struct {
+	user       entities.User
+	employee   entities.Employee
+	year       int
+	occurrence time.Time
+	// owner is set for the birthday person, who may only read the card from the day itself.
+	owner bool
+}
+
+// WriteMessage leaves the current user's message on the card or replaces it. Subscribers of
+// the employee and admins may write until the birthday is over.
+func WriteMessage(log *slog.Logger, boardRepo Board, empRepo Employees, subsRepo Subscribers, userRepo jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.board.write"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		card, ok := loadBoard(w, r, log, empRepo, subsRepo, userRepo)
+		if !ok {
+			return
+		}
+		if card.owner {
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, response.Error("you cannot write on your own card"))
+			return
+		}
+		if schedule.LocalDate(card.user, time.Now()).After(card.occurrence) {
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, response.Error("the birthday is over"))
+			return
+		}
+		var req RequestMessage
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", errMsg.Err(err))
+			render.JSON(w, r, response.Error("failed to decode request"))
+			return
+		}
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("Invalid request", errMsg.Err(err))
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		message := entities.BoardMessage{
+			EmployeeID:  card.employee.ID,
+			Year:        card.year,
+			AuthorID:    card.user.ID,
+			AuthorEmail: card.user.Email,
+			Body:        req.Body,
+		}
+		if err := boardRepo.SaveMessage(r.Context(), &message); err != nil {
+			log.Error("Failed to save message", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to save message"))
+			return
+		}
+		log.Info("board message saved", slog.Int("emp_id", card.employee.ID), slog.Int("year", card.year),
+			slog.Int("user_id", card.user.ID))
+		render.JSON(w, r, ResponseMessage{Response: response.OK(), Message: message})
+	}
+}
+
+// ListMessages shows the card to subscribers and admins at any time, and to the birthday person
+// from the birthday on.
+func ListMessages(log *slog.Logger, boardRepo Board, empRepo Employees, subsRepo Subscribers, userRepo jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.board.list"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		card, ok := loadBoard(w, r, log, empRepo, subsRepo, userRepo)
+		if !ok {
+			return
+		}
+		if card.owner && schedule.LocalDate(card.user, time.Now()).Before(card.occurrence) {
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, response.Error("the card is revealed on your birthday"))
+			return
+		}
+		messages, err := boardRepo.GetMessages(r.Context(), card.employee.ID, card.year)
+		if err != nil {
+			log.Error("Failed to get messages", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to get messages"))
+			return
+		}
+		render.JSON(w, r, ResponseBoard{Response: response.OK(), EmployeeID: card.employee.ID, Name: card.employee.Name,
+			Occurrence: card.occurrence, Messages: messages})
+	}
+}
+
+// DeleteMessage removes the current user's own message.
+func DeleteMessage(log *slog.Logger, boardRepo Board, empRepo Employees, subsRepo Subscribers, userRepo jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.board.delete"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		card, ok := loadBoard(w, r, log, empRepo, subsRepo, userRepo)
+		if !ok {
+			return
+		}
+		deleted, err := boardRepo.DeleteMessage(r.Context(), card.employee.ID, card.year, card.user.ID)
+		if err != nil {
+			log.Error("Failed to delete message", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to delete message"))
+			return
+		}
+		if !deleted {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("message not found"))
+			return
+		}
+		render.JSON(w, r, response.OK())
+	}
+}
+
+// loadBoard resolves the card from the URL and checks that the current user is invited to it:
+// a subscriber of the employee, an admin or the birthday person.
+func loadBoard(w http.ResponseWriter, r *http.Request, log *slog.Logger, empRepo Employees, subsRepo Subscribers,
+	userRepo jwt.UserFinder) (board, bool) {
+	user, ok := jwt.CurrentUser(w, r, log, userRepo)
+	if !ok {
+		return board{}, false
+	}
+	empID, err := strconv.Atoi(chi.URLParam(r, "empId"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, response.Error("Invalid employee ID"))
+		return board{}, false
+	}
+	year, err := strconv.Atoi(chi.URLParam(r, "year"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, response.Error("Invalid year"))
+		return board{}, false
+	}
+	employee, err := empRepo.FindEmployeeById(r.Context(), empID)
+	if err != nil || employee.ArchivedAt != nil || year <= employee.Birthday.Year() {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, response.Error("card not found"))
+		return board{}, false
+	}
+
+	card := board{
+		user:       user,
+		employee:   employee,
+		year:       year,
+		occurrence: notification.NextBirthday(employee.Birthday, time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		owner:      user.EmployeeID != 0 && user.EmployeeID == employee.ID,
+	}
+	if card.owner || jwt.IsAdmin(r.Context()) {
+		return card, true
+	}
+	if privacy.Effective(employee, false) == privacy.VisibilityHidden {
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, response.Error("the employee's birthday is hidden"))
+		return board{}, false
+	}
+	subscribed, err := subsRepo.IsSubscribed(r.Context(), user.ID, employee.ID)
+	if err != nil {
+		log.Error("Failed to check subscription", errMsg.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, response.Error("Failed to get card"))
+		return board{}, false
+	}
+	if subscribed {
+		return card, true
+	}
+	render.Status(r, http.StatusForbidden)
+	render.JSON(w, r, response.Error("only subscribers of the employee can see the card"))
+	return board{}, false
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	"birthday-service/internal/calendar"
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type Holidays interface {
+	SaveHoliday(ctx context.Context, holiday entities.Holiday) error
+	DeleteHoliday(ctx context.Context, day time.Time) (bool, error)
+}
+
+type RequestHoliday struct {
+	Name string `json:"name"`
+	// Working marks a weekend day that is a working day, e.g. after a holiday transfer.
+	Working bool `json:"working"`
+}
+
+type ResponseHolidays struct {
+	response.Response
+	Holidays []entities.Holiday `json:"holidays"`
+}
+
+const dateLayout = "2006-01-02"
+
+// ListHolidays returns the holidays and working weekend days of a year (the current one by default),
+// merging the configured holidays file with the days managed through the API.
+func ListHolidays(log *slog.Logger, workdays *calendar.Loader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.calendar.ListHolidays"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		year := time.Now().Year()
+		if raw := r.URL.Query().Get("year"); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("Invalid year"))
+				return
+			}
+			year = parsed
+		}
+		from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+		cal, err := workdays.Load(r.Context(), from, to)
+		if err != nil {
+			log.Error("Failed to load calendar", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to load calendar"))
+			return
+		}
+		render.JSON(w, r, ResponseHolidays{Response: response.OK(), Holidays: cal.Holidays(from, to)})
+	}
+}
+
+func SaveHoliday(log *slog.Logger, holidayRepository Holidays) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.calendar.SaveHoliday"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		day, err := time.Parse(dateLayout, chi.URLParam(r, "date"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid date, expected YYYY-MM-DD"))
+			return
+		}
+		var req RequestHoliday
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", errMsg.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("failed to decode request"))
+			return
+		}
+
+		holiday := entities.Holiday{Date: day, Name: req.Name, Working: req.Working}
+		if err := holidayRepository.SaveHoliday(r.Context(), holiday); err != nil {
+			log.Error("Failed to save holiday", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to save holiday"))
+			return
+		}
+		log.Info("holiday saved", slog.String("date", day.Format(dateLayout)), slog.Bool("working", req.Working))
+		render.JSON(w, r, response.OK())
+	}
+}
+
+func DeleteHoliday(log *slog.Logger, holidayRepository Holidays) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.calendar.DeleteHoliday"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		day, err := time.Parse(dateLayout, chi.URLParam(r, "date"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid date, expected YYYY-MM-DD"))
+			return
+		}
+		deleted, err := holidayRepository.DeleteHoliday(r.Context(), day)
+		if err != nil {
+			log.Error("Failed to delete holiday", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to delete holiday"))
+			return
+		}
+		if !deleted {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("holiday not found"))
+			return
+		}
+		log.Info("holiday deleted", slog.String("date", day.Format(dateLayout)))
+		render.JSON(w, r, response.OK())
+	}
+}
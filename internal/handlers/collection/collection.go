@@ -0,0 +1,409 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/internal/notification"
+	"birthday-service/internal/privacy"
+	"birthday-service/jwt"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+)
+
+type Collections interface {
+	CreateCollection(ctx context.Context, collection *entities.Collection) (bool, error)
+	GetCollection(ctx context.Context, id int) (entities.Collection, bool, error)
+	ListCollections(ctx context.Context, excludeEmployeeID int) ([]entities.Collection, error)
+	CloseCollection(ctx context.Context, id int) error
+	SavePledge(ctx context.Context, collectionID, userID, amount int, paid bool) error
+	SetPledgePaid(ctx context.Context, collectionID, userID int, paid bool) (bool, error)
+	DeletePledge(ctx context.Context, collectionID, userID int) (bool, error)
+	GetPledges(ctx context.Context, collectionID int) ([]entities.Pledge, error)
+}
+
+type Employees interface {
+	FindEmployeeById(ctx context.Context, id int) (entities.Employee, error)
+}
+
+type Subscribers interface {
+	GetSubs(ctx context.Context, EmployeeID int) ([]entities.User, error)
+	IsSubscribed(ctx context.Context, userID, employeeID int) (bool, error)
+}
+
+type Inviter interface {
+	InviteToCollection(ctx context.Context, collection entities.Collection, recipients []entities.User) (int, error)
+}
+
+type RequestCollection struct {
+	EmpID  int    `json:"emp_id" validate:"required"`
+	Title  string `json:"title" validate:"max=255"`
+	Target int    `json:"target" validate:"min=0"`
+}
+
+type RequestPledge struct {
+	Amount int  `json:"amount" validate:"required,min=1"`
+	Paid   bool `json:"paid"`
+}
+
+type RequestPaid struct {
+	Paid bool `json:"paid"`
+}
+
+type ResponseCollection struct {
+	response.Response
+	Collection entities.Collection `json:"collection"`
+	Created    bool                `json:"created,omitempty"`
+	Invited    int                 `json:"invited,omitempty"`
+	// Pledges lists every pledge for the organiser and admins, and only the viewer's own otherwise.
+	Pledges []entities.Pledge `json:"pledges,omitempty"`
+}
+
+type ResponseCollections struct {
+	response.Response
+	Collections []entities.Collection `json:"collections"`
+}
+
+// OpenCollection starts a collection for the employee's next birthday and invites everyone
+// subscribed to the employee except the organiser.
+func OpenCollection(log *slog.Logger, collectionRepo Collections, empRepo Employees, subsRepo Subscribers,
+	userRepo jwt.UserFinder, inviter Inviter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.collection.open"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := jwt.CurrentUser(w, r, log, userRepo)
+		if !ok {
+			return
+		}
+		var req RequestCollection
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", errMsg.Err(err))
+			render.JSON(w, r, response.Error("failed to decode request"))
+			return
+		}
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("Invalid request", errMsg.Err(err))
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+		if req.EmpID == user.EmployeeID {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("you cannot open a collection for your own birthday"))
+			return
+		}
+
+		employee, err := empRepo.FindEmployeeById(r.Context(), req.EmpID)
+		if err != nil || employee.ArchivedAt != nil {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("employee not found"))
+			return
+		}
+		if privacy.Effective(employee, jwt.IsAdmin(r.Context())) == privacy.VisibilityHidden {
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, response.Error("the employee's birthday is hidden"))
+			return
+		}
+
+		collection := entities.Collection{
+			EmployeeID:   employee.ID,
+			EmployeeName: employee.Name,
+			Occurrence:   notification.NextBirthday(employee.Birthday, time.Now().UTC()),
+			OrganiserID:  user.ID,
+			Title:        req.Title,
+			Target:       req.Target,
+		}
+		created, err := collectionRepo.CreateCollection(r.Context(), &collection)
+		if err != nil {
+			log.Error("Failed to open collection", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to open collection"))
+			return
+		}
+		if !created {
+			existing, _, err := collectionRepo.GetCollection(r.Context(), collection.ID)
+			if err != nil {
+				log.Error("Failed to get collection", errMsg.Err(err))
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.Error("Failed to open collection"))
+				return
+			}
+			render.JSON(w, r, ResponseCollection{Response: response.OK(), Collection: existing})
+			return
+		}
+
+		subscribers, err := subsRepo.GetSubs(r.Context(), employee.ID)
+		if err != nil {
+			log.Error("Failed to get subscribers", errMsg.Err(err))
+		}
+		recipients := make([]entities.User, 0, len(subscribers))
+		for _, subscriber := range subscribers {
+			if subscriber.ID != user.ID {
+				recipients = append(recipients, subscriber)
+			}
+		}
+		invited, err := inviter.InviteToCollection(r.Context(), collection, recipients)
+		if err != nil {
+			log.Error("Failed to send invitations", errMsg.Err(err))
+		}
+		log.Info("collection opened", slog.Int("collection_id", collection.ID), slog.Int("emp_id", employee.ID),
+			slog.Int("invited", invited))
+		render.JSON(w, r, ResponseCollection{Response: response.OK(), Collection: collection, Created: true, Invited: invited})
+	}
+}
+
+// ListCollections returns open collections except the viewer's own birthday.
+func ListCollections(log *slog.Logger, collectionRepo Collections, userRepo jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.collection.list"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := jwt.CurrentUser(w, r, log, userRepo)
+		if !ok {
+			return
+		}
+		collections, err := collectionRepo.ListCollections(r.Context(), user.EmployeeID)
+		if err != nil {
+			log.Error("Failed to list collections", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to list collections"))
+			return
+		}
+		render.JSON(w, r, ResponseCollections{Response: response.OK(), Collections: collections})
+	}
+}
+
+func GetCollection(log *slog.Logger, collectionRepo Collections, userRepo jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.collection.get"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, collection, ok := visibleCollection(w, r, log, collectionRepo, userRepo)
+		if !ok {
+			return
+		}
+		pledges, err := collectionRepo.GetPledges(r.Context(), collection.ID)
+		if err != nil {
+			log.Error("Failed to get pledges", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to get collection"))
+			return
+		}
+		if !canManage(r, user, collection) {
+			own := pledges[:0]
+			for _, pledge := range pledges {
+				if pledge.UserID == user.ID {
+					own = append(own, pledge)
+				}
+			}
+			pledges = own
+		}
+		render.JSON(w, r, ResponseCollection{Response: response.OK(), Collection: collection, Pledges: pledges})
+	}
+}
+
+// SavePledge records how much the current user chips in and whether they have paid. Only
+// subscribers of the employee, the organiser and admins may pledge.
+func SavePledge(log *slog.Logger, collectionRepo Collections, subsRepo Subscribers, userRepo jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.collection.savePledge"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, collection, ok := visibleCollection(w, r, log, collectionRepo, userRepo)
+		if !ok {
+			return
+		}
+		if collection.ClosedAt != nil {
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, response.Error("collection is closed"))
+			return
+		}
+		if !canManage(r, user, collection) {
+			subscribed, err := subsRepo.IsSubscribed(r.Context(), user.ID, collection.EmployeeID)
+			if err != nil {
+				log.Error("Failed to check subscription", errMsg.Err(err))
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.Error("Failed to save pledge"))
+				return
+			}
+			if !subscribed {
+				render.Status(r, http.StatusForbidden)
+				render.JSON(w, r, response.Error("only subscribers of the employee can pledge"))
+				return
+			}
+		}
+		var req RequestPledge
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", errMsg.Err(err))
+			render.JSON(w, r, response.Error("failed to decode request"))
+			return
+		}
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("Invalid request", errMsg.Err(err))
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+		if err := collectionRepo.SavePledge(r.Context(), collection.ID, user.ID, req.Amount, req.Paid); err != nil {
+			log.Error("Failed to save pledge", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to save pledge"))
+			return
+		}
+		log.Info("pledge saved", slog.Int("collection_id", collection.ID), slog.Int("user_id", user.ID))
+		render.JSON(w, r, response.OK())
+	}
+}
+
+func DeletePledge(log *slog.Logger, collectionRepo Collections, userRepo jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.collection.deletePledge"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, collection, ok := visibleCollection(w, r, log, collectionRepo, userRepo)
+		if !ok {
+			return
+		}
+		deleted, err := collectionRepo.DeletePledge(r.Context(), collection.ID, user.ID)
+		if err != nil {
+			log.Error("Failed to delete pledge", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to delete pledge"))
+			return
+		}
+		if !deleted {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("pledge not found"))
+			return
+		}
+		render.JSON(w, r, response.OK())
+	}
+}
+
+// SetPledgePaid lets the organiser confirm a colleague's payment.
+func SetPledgePaid(log *slog.Logger, collectionRepo Collections, userRepo jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.collection.setPaid"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, collection, ok := visibleCollection(w, r, log, collectionRepo, userRepo)
+		if !ok {
+			return
+		}
+		if !canManage(r, user, collection) {
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, response.Error("only the organiser can confirm payments"))
+			return
+		}
+		pledgerID, err := strconv.Atoi(chi.URLParam(r, "userId"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid user ID"))
+			return
+		}
+		var req RequestPaid
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", errMsg.Err(err))
+			render.JSON(w, r, response.Error("failed to decode request"))
+			return
+		}
+		found, err := collectionRepo.SetPledgePaid(r.Context(), collection.ID, pledgerID, req.Paid)
+		if err != nil {
+			log.Error("Failed to update pledge", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to update pledge"))
+			return
+		}
+		if !found {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("pledge not found"))
+			return
+		}
+		log.Info("pledge payment updated", slog.Int("collection_id", collection.ID), slog.Int("user_id", pledgerID),
+			slog.Bool("paid", req.Paid))
+		render.JSON(w, r, response.OK())
+	}
+}
+
+func CloseCollection(log *slog.Logger, collectionRepo Collections, userRepo jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.collection.close"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, collection, ok := visibleCollection(w, r, log, collectionRepo, userRepo)
+		if !ok {
+			return
+		}
+		if !canManage(r, user, collection) {
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, response.Error("only the organiser can close the collection"))
+			return
+		}
+		if err := collectionRepo.CloseCollection(r.Context(), collection.ID); err != nil {
+			log.Error("Failed to close collection", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to close collection"))
+			return
+		}
+		log.Info("collection closed", slog.Int("collection_id", collection.ID))
+		render.JSON(w, r, response.OK())
+	}
+}
+
+// visibleCollection loads the collection from the URL; the birthday person gets 404 so the
+// surprise is kept.
+func visibleCollection(w http.ResponseWriter, r *http.Request, log *slog.Logger, collectionRepo Collections,
+	userRepo jwt.UserFinder) (entities.User, entities.Collection, bool) {
+	user, ok := jwt.CurrentUser(w, r, log, userRepo)
+	if !ok {
+		return entities.User{}, entities.Collection{}, false
+	}
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, response.Error("Invalid collection ID"))
+		return entities.User{}, entities.Collection{}, false
+	}
+	collection, found, err := collectionRepo.GetCollection(r.Context(), id)
+	if err != nil {
+		log.Error("Failed to get collection", errMsg.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, response.Error("Failed to get collection"))
+		return entities.User{}, entities.Collection{}, false
+	}
+	if !found || (user.EmployeeID != 0 && user.EmployeeID == collection.EmployeeID) {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, response.Error("collection not found"))
+		return entities.User{}, entities.Collection{}, false
+	}
+	return user, collection, true
+}
+
+func canManage(r *http.Request, user entities.User, collection entities.Collection) bool {
+	return user.ID == collection.OrganiserID || jwt.IsAdmin(r.Context())
+}
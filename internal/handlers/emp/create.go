@@ -17,13 +17,24 @@ import (
 type Employee interface {
 	CreateEmployee(ctx context.Context, employee *entities.Employee) error
 	DeleteEmpById(ctx context.Context, id int) error
-	GetAllEmp(ctx context.Context) ([]entities.Employee, error)
-	GetUpcomingBirthdays(ctx context.Context) ([]entities.Employee, error)
+	ArchiveEmpById(ctx context.Context, id int) error
+	RestoreEmpById(ctx context.Context, id int) (bool, error)
+	GetAllEmp(ctx context.Context, includeArchived bool) ([]entities.Employee, error)
+	GetUpcomingBirthdays(ctx context.Context, from, to time.Time) ([]entities.UpcomingBirthday, error)
+	GetUpcomingWithSubscribers(ctx context.Context, from, to, now time.Time) ([]entities.UpcomingEvent, error)
+	ImportEmployees(ctx context.Context, employees []entities.Employee, dryRun bool) (int, int, error)
+	StreamEmployees(ctx context.Context, includeArchived bool, fn func(entities.Employee) error) error
+	SetManager(ctx context.Context, id, managerID int) error
+	GetReportingChain(ctx context.Context, id int) ([]entities.OrgNode, error)
+	GetReports(ctx context.Context, id int, depth int) ([]entities.OrgNode, error)
+	SetPrivacy(ctx context.Context, id int, visibility string, optOut *bool) error
 }
 
 type RequestEmp struct {
-	Name     string     `json:"name" validate:"required"`
-	Birthday CustomDate `json:"birthday" validate:"required"`
+	Name      string     `json:"name" validate:"required"`
+	Birthday  CustomDate `json:"birthday" validate:"required"`
+	ManagerID int        `json:"manager_id"`
+	Email     string     `json:"email" validate:"omitempty,email"`
 }
 
 type ResponseEmp struct {
@@ -73,7 +84,7 @@ func New(log *slog.Logger, empRepository Employee) http.HandlerFunc {
 			render.JSON(w, r, response.ValidationError(validateErr))
 			return
 		}
-		emp := entities.Employee{Name: req.Name, Birthday: req.Birthday.ToTime()}
+		emp := entities.Employee{Name: req.Name, Birthday: req.Birthday.ToTime(), ManagerID: req.ManagerID, Email: req.Email}
 		err = empRepository.CreateEmployee(r.Context(), &emp)
 		if err != nil {
 			log.Error("Failed to create employee", errMsg.Err(err))
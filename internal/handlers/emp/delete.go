@@ -3,11 +3,12 @@ package handlers
 import (
 	"birthday-service/api/response"
 	errMsg "birthday-service/internal/err"
+	"birthday-service/jwt"
 	"log/slog"
 	"net/http"
 	"strconv"
 
-	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 )
@@ -35,15 +36,56 @@ func DeleteEmpHandler(log *slog.Logger, empRepo Employee) http.HandlerFunc {
 			return
 		}
 
-		err = empRepo.DeleteEmpById(r.Context(), id)
+		hard, _ := strconv.ParseBool(r.URL.Query().Get("hard"))
+		if hard && !jwt.IsAdmin(r.Context()) {
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, response.Error("forbidden"))
+			return
+		}
+		if hard {
+			err = empRepo.DeleteEmpById(r.Context(), id)
+		} else {
+			err = empRepo.ArchiveEmpById(r.Context(), id)
+		}
 		if err != nil {
 			log.Error("Failed to delete employee", errMsg.Err(err))
 			render.Status(r, http.StatusInternalServerError)
 			render.JSON(w, r, response.Error("Failed to delete employee"))
 			return
 		}
-		log.Info("employee deleted")
+		log.Info("employee deleted", slog.Bool("hard", hard))
 		render.Status(r, http.StatusNoContent)
 		render.JSON(w, r, response.OK())
 	}
 }
+
+func RestoreEmpHandler(log *slog.Logger, empRepo Employee) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.restore.employee"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid emp ID"))
+			return
+		}
+
+		restored, err := empRepo.RestoreEmpById(r.Context(), id)
+		if err != nil {
+			log.Error("Failed to restore employee", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to restore employee"))
+			return
+		}
+		if !restored {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("archived employee not found"))
+			return
+		}
+		log.Info("employee restored", slog.Int("emp_id", id))
+		render.JSON(w, r, response.OK())
+	}
+}
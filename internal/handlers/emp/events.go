@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/jwt"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+)
+
+type Events interface {
+	CreateEvent(ctx context.Context, event *entities.Event) (bool, error)
+	GetEvents(ctx context.Context, employeeID int) ([]entities.Event, error)
+	DeleteEvent(ctx context.Context, employeeID, id int) (bool, error)
+}
+
+type RequestEvent struct {
+	Type string `json:"type" validate:"required,oneof=work_anniversary custom"`
+	// Name is required for custom events, e.g. "name day".
+	Name       string     `json:"name"`
+	Date       CustomDate `json:"date" validate:"required"`
+	Recurrence string     `json:"recurrence" validate:"omitempty,oneof=yearly once"`
+}
+
+type ResponseEvent struct {
+	response.Response
+	Event entities.Event `json:"event"`
+}
+
+type ResponseEvents struct {
+	response.Response
+	Events []entities.Event `json:"events"`
+}
+
+// NewEventHandler adds an event to the employee; only admins and the linked user may do so.
+func NewEventHandler(log *slog.Logger, eventRepository Events, userRepository jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.emp.newEvent"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid emp ID"))
+			return
+		}
+		if !canManageEmployee(w, r, log, userRepository, id) {
+			return
+		}
+		var req RequestEvent
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", errMsg.Err(err))
+			render.JSON(w, r, response.Error("failed to decode request"))
+			return
+		}
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("Invalid request", errMsg.Err(err))
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+		if req.Type == entities.EventCustom && req.Name == "" {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("custom events need a name"))
+			return
+		}
+		if req.Recurrence == "" {
+			req.Recurrence = entities.RecurrenceYearly
+		}
+
+		event := entities.Event{EmployeeID: id, Type: req.Type, Name: req.Name, Date: req.Date.ToTime(),
+			Recurrence: req.Recurrence}
+		found, err := eventRepository.CreateEvent(r.Context(), &event)
+		if err != nil {
+			log.Error("Failed to create event", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to create event"))
+			return
+		}
+		if !found {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("employee not found"))
+			return
+		}
+		log.Info("event saved", slog.Int("emp_id", id), slog.Int("event_id", event.ID), slog.String("type", event.Type))
+		render.JSON(w, r, ResponseEvent{Response: response.OK(), Event: event})
+	}
+}
+
+func ListEventsHandler(log *slog.Logger, eventRepository Events) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.emp.listEvents"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid emp ID"))
+			return
+		}
+		events, err := eventRepository.GetEvents(r.Context(), id)
+		if err != nil {
+			log.Error("Failed to get events", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to get events"))
+			return
+		}
+		render.JSON(w, r, ResponseEvents{Response: response.OK(), Events: events})
+	}
+}
+
+// DeleteEventHandler removes an event of the employee; only admins and the linked user may do so.
+func DeleteEventHandler(log *slog.Logger, eventRepository Events, userRepository jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.emp.deleteEvent"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid emp ID"))
+			return
+		}
+		if !canManageEmployee(w, r, log, userRepository, id) {
+			return
+		}
+		eventID, err := strconv.Atoi(chi.URLParam(r, "eventId"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid event ID"))
+			return
+		}
+		deleted, err := eventRepository.DeleteEvent(r.Context(), id, eventID)
+		if err != nil {
+			log.Error("Failed to delete event", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to delete event"))
+			return
+		}
+		if !deleted {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("event not found"))
+			return
+		}
+		log.Info("event deleted", slog.Int("emp_id", id), slog.Int("event_id", eventID))
+		render.JSON(w, r, response.OK())
+	}
+}
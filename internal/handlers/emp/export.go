@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/internal/export"
+	"birthday-service/jwt"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+func ExportEmployees(log *slog.Logger, empRepository Employee) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.exportEmployees"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = export.FormatJSON
+		}
+		if !export.Supported(format) {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("unsupported export format"))
+			return
+		}
+
+		w.Header().Set("Content-Type", export.ContentType(format))
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="employees.%s"`, format))
+		writer, err := export.NewEmployeeWriter(w, format, jwt.IsAdmin(r.Context()))
+		if err != nil {
+			log.Error("failed to start export", errMsg.Err(err))
+			return
+		}
+
+		// Archived employees are only listed for admins.
+		includeArchived, _ := strconv.ParseBool(r.URL.Query().Get("include_archived"))
+		includeArchived = includeArchived && jwt.IsAdmin(r.Context())
+		err = empRepository.StreamEmployees(r.Context(), includeArchived, func(employee entities.Employee) error {
+			return writer.Write(employee)
+		})
+		if err == nil {
+			err = writer.Close()
+		}
+		if err != nil {
+			log.Error("failed to export employees", errMsg.Err(err))
+			return
+		}
+		log.Info("employees exported", slog.String("format", format))
+	}
+}
@@ -2,10 +2,12 @@ package handlers
 
 import (
 	"birthday-service/api/response"
-	"birthday-service/internal/entities"
 	errMsg "birthday-service/internal/err"
+	"birthday-service/internal/privacy"
+	"birthday-service/jwt"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
@@ -13,7 +15,7 @@ import (
 
 type ResponseEmpList struct {
 	response.Response
-	Employees []entities.Employee `json:"employees"`
+	Employees []privacy.EmployeeView `json:"employees"`
 }
 
 func ListAllEmployees(log *slog.Logger, empRepository Employee) http.HandlerFunc {
@@ -24,7 +26,10 @@ func ListAllEmployees(log *slog.Logger, empRepository Employee) http.HandlerFunc
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
-		employees, err := empRepository.GetAllEmp(r.Context())
+		// Archived employees are only listed for admins.
+		includeArchived, _ := strconv.ParseBool(r.URL.Query().Get("include_archived"))
+		includeArchived = includeArchived && jwt.IsAdmin(r.Context())
+		employees, err := empRepository.GetAllEmp(r.Context(), includeArchived)
 		if err != nil {
 			log.Error("Failed to retrieve employees", errMsg.Err(err))
 			render.JSON(w, r, response.Error("Failed to retrieve employees"))
@@ -32,11 +37,11 @@ func ListAllEmployees(log *slog.Logger, empRepository Employee) http.HandlerFunc
 		}
 		log.Info("employees retrieved", slog.Any("employees", employees))
 
-		responseOKgetEmp(w, r, employees)
+		responseOKgetEmp(w, r, privacy.Views(employees, jwt.IsAdmin(r.Context())))
 	}
 }
 
-func responseOKgetEmp(w http.ResponseWriter, r *http.Request, employees []entities.Employee) {
+func responseOKgetEmp(w http.ResponseWriter, r *http.Request, employees []privacy.EmployeeView) {
 	render.JSON(w, r, ResponseEmpList{
 		Response:  response.OK(),
 		Employees: employees,
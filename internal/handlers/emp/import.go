@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/internal/importer"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+const maxImportSize = 10 << 20
+
+type ResponseImport struct {
+	response.Response
+	DryRun  bool                `json:"dry_run"`
+	Total   int                 `json:"total"`
+	Created int                 `json:"created"`
+	Updated int                 `json:"updated"`
+	Errors  []importer.RowError `json:"errors,omitempty"`
+}
+
+func ImportEmployees(log *slog.Logger, empRepository Employee) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.importEmployees"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		query := r.URL.Query()
+		dryRun, _ := strconv.ParseBool(query.Get("dry_run"))
+		mapping := importer.DefaultMapping()
+		if column := query.Get("name_column"); column != "" {
+			mapping.Name = column
+		}
+		if column := query.Get("birthday_column"); column != "" {
+			mapping.Birthday = column
+		}
+		if column := query.Get("external_id_column"); column != "" {
+			mapping.ExternalID = column
+		}
+		if column := query.Get("email_column"); column != "" {
+			mapping.Email = column
+		}
+
+		body, format, err := importSource(r)
+		if err != nil {
+			log.Error("failed to read import file", errMsg.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("failed to read import file"))
+			return
+		}
+		defer body.Close()
+		if f := query.Get("format"); f != "" {
+			format = strings.ToLower(f)
+		}
+
+		result, err := importer.Read(body, format, mapping, query["date_format"])
+		if err != nil {
+			log.Error("failed to parse import file", errMsg.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error(err.Error()))
+			return
+		}
+
+		resp := ResponseImport{
+			Response: response.OK(),
+			DryRun:   dryRun,
+			Total:    len(result.Employees) + len(result.Errors),
+			Errors:   result.Errors,
+		}
+		if len(result.Errors) > 0 && !dryRun {
+			log.Info("import rejected", slog.Int("errors", len(result.Errors)))
+			resp.Response = response.Error("import file contains invalid rows")
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, resp)
+			return
+		}
+
+		resp.Created, resp.Updated, err = empRepository.ImportEmployees(r.Context(), result.Employees, dryRun)
+		if err != nil {
+			log.Error("failed to import employees", errMsg.Err(err))
+			resp.Response = response.Error("Failed to import employees: " + err.Error())
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, resp)
+			return
+		}
+		log.Info("employees imported", slog.Bool("dry_run", dryRun),
+			slog.Int("created", resp.Created), slog.Int("updated", resp.Updated))
+		render.JSON(w, r, resp)
+	}
+}
+
+func importSource(r *http.Request) (io.ReadCloser, string, error) {
+	r.Body = http.MaxBytesReader(nil, r.Body, maxImportSize)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			return nil, "", err
+		}
+		return file, importer.FormatFromFilename(header.Filename), nil
+	}
+
+	format := importer.FormatCSV
+	if strings.Contains(r.Header.Get("Content-Type"), "spreadsheetml") {
+		format = importer.FormatXLSX
+	}
+	return r.Body, format, nil
+}
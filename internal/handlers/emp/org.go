@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/internal/privacy"
+	"birthday-service/jwt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type RequestManager struct {
+	ManagerID int `json:"manager_id"`
+}
+
+type OrgNodeView struct {
+	privacy.EmployeeView
+	Level int `json:"level"`
+}
+
+type ResponseOrg struct {
+	response.Response
+	Employees []OrgNodeView `json:"employees"`
+}
+
+func SetManagerHandler(log *slog.Logger, empRepository Employee) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.emp.setManager"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid emp ID"))
+			return
+		}
+		var req RequestManager
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", errMsg.Err(err))
+			render.JSON(w, r, response.Error("failed to decode request"))
+			return
+		}
+
+		if req.ManagerID != 0 {
+			chain, err := empRepository.GetReportingChain(r.Context(), req.ManagerID)
+			if err != nil {
+				log.Error("Failed to check reporting chain", errMsg.Err(err))
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.Error("Failed to set manager"))
+				return
+			}
+			if req.ManagerID == id || containsEmployee(chain, id) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("manager would create a reporting cycle"))
+				return
+			}
+		}
+
+		err = empRepository.SetManager(r.Context(), id, req.ManagerID)
+		if err != nil {
+			log.Error("Failed to set manager", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to set manager"))
+			return
+		}
+		log.Info("manager updated", slog.Int("emp_id", id), slog.Int("manager_id", req.ManagerID))
+		render.JSON(w, r, response.OK())
+	}
+}
+
+func ReportingChainHandler(log *slog.Logger, empRepository Employee) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.emp.reportingChain"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid emp ID"))
+			return
+		}
+
+		chain, err := empRepository.GetReportingChain(r.Context(), id)
+		if err != nil {
+			log.Error("Failed to retrieve reporting chain", errMsg.Err(err))
+			render.JSON(w, r, response.Error("Failed to retrieve reporting chain"))
+			return
+		}
+		render.JSON(w, r, ResponseOrg{Response: response.OK(), Employees: orgViews(chain, jwt.IsAdmin(r.Context()))})
+	}
+}
+
+func ReportsHandler(log *slog.Logger, empRepository Employee) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.emp.reports"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid emp ID"))
+			return
+		}
+		depth := 0
+		if depthStr := r.URL.Query().Get("depth"); depthStr != "" {
+			depth, err = strconv.Atoi(depthStr)
+			if err != nil || depth < 0 {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("Invalid depth"))
+				return
+			}
+		}
+
+		reports, err := empRepository.GetReports(r.Context(), id, depth)
+		if err != nil {
+			log.Error("Failed to retrieve reports", errMsg.Err(err))
+			render.JSON(w, r, response.Error("Failed to retrieve reports"))
+			return
+		}
+		render.JSON(w, r, ResponseOrg{Response: response.OK(), Employees: orgViews(reports, jwt.IsAdmin(r.Context()))})
+	}
+}
+
+func orgViews(nodes []entities.OrgNode, admin bool) []OrgNodeView {
+	views := make([]OrgNodeView, 0, len(nodes))
+	for _, node := range nodes {
+		views = append(views, OrgNodeView{EmployeeView: privacy.View(node.Employee, admin), Level: node.Level})
+	}
+	return views
+}
+
+func containsEmployee(nodes []entities.OrgNode, id int) bool {
+	for _, node := range nodes {
+		if node.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/internal/privacy"
+	"birthday-service/jwt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+// RequestPrivacy changes only the settings present in the request.
+type RequestPrivacy struct {
+	Visibility string `json:"birthday_visibility"`
+	OptOut     *bool  `json:"notifications_opt_out"`
+}
+
+// SetPrivacyHandler lets admins and the user linked to the employee change privacy settings.
+func SetPrivacyHandler(log *slog.Logger, empRepository Employee, userRepository jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.emp.setPrivacy"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid emp ID"))
+			return
+		}
+		if !canManageEmployee(w, r, log, userRepository, id) {
+			return
+		}
+
+		var req RequestPrivacy
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", errMsg.Err(err))
+			render.JSON(w, r, response.Error("failed to decode request"))
+			return
+		}
+		if req.Visibility != "" && !privacy.ValidVisibility(req.Visibility) {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("birthday_visibility must be one of full, day_month, hidden"))
+			return
+		}
+
+		err = empRepository.SetPrivacy(r.Context(), id, req.Visibility, req.OptOut)
+		if err != nil {
+			log.Error("Failed to update privacy settings", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to update privacy settings"))
+			return
+		}
+		log.Info("privacy settings updated", slog.Int("emp_id", id))
+		render.JSON(w, r, response.OK())
+	}
+}
+
+func canManageEmployee(w http.ResponseWriter, r *http.Request, log *slog.Logger, userRepository jwt.UserFinder, empID int) bool {
+	if jwt.IsAdmin(r.Context()) {
+		return true
+	}
+	user, ok := jwt.CurrentUser(w, r, log, userRepository)
+	if !ok {
+		return false
+	}
+	if user.EmployeeID != empID {
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, response.Error("forbidden"))
+		return false
+	}
+	return true
+}
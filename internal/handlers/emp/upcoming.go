@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/internal/milestone"
+	"birthday-service/internal/privacy"
+	"birthday-service/jwt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+const maxUpcomingDays = 366
+
+type UpcomingBirthdayView struct {
+	privacy.EmployeeView
+	Date     string `json:"date"`
+	DaysLeft int    `json:"days_left"`
+	// Age and Milestone are shown only to viewers allowed to see the birth year.
+	Age       int  `json:"age,omitempty"`
+	Milestone bool `json:"milestone,omitempty"`
+}
+
+type ResponseUpcoming struct {
+	response.Response
+	Birthdays []UpcomingBirthdayView `json:"birthdays"`
+}
+
+// UpcomingBirthdaysHandler lists birthdays in the next ?days= days (7 by default). Hidden
+// birthdays are left out for everyone but admins.
+func UpcomingBirthdaysHandler(log *slog.Logger, empRepository Employee, milestones milestone.Rules) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.emp.upcomingBirthdays"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		days := 7
+		if raw := r.URL.Query().Get("days"); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil || parsed < 0 || parsed > maxUpcomingDays {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("days must be between 0 and 366"))
+				return
+			}
+			days = parsed
+		}
+
+		now := time.Now().UTC()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		birthdays, err := empRepository.GetUpcomingBirthdays(r.Context(), today, today.AddDate(0, 0, days))
+		if err != nil {
+			log.Error("Failed to get upcoming birthdays", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to get upcoming birthdays"))
+			return
+		}
+
+		admin := jwt.IsAdmin(r.Context())
+		views := make([]UpcomingBirthdayView, 0, len(birthdays))
+		for _, birthday := range birthdays {
+			visibility := privacy.Effective(birthday.Employee, admin)
+			if visibility == privacy.VisibilityHidden {
+				continue
+			}
+			view := UpcomingBirthdayView{
+				EmployeeView: privacy.View(birthday.Employee, admin),
+				Date:         birthday.Date.Format("2006-01-02"),
+				DaysLeft:     int(birthday.Date.Sub(today).Hours() / 24),
+			}
+			if visibility == privacy.VisibilityFull {
+				view.Age = birthday.Age
+				_, view.Milestone = milestones.Match(birthday.Age)
+			}
+			views = append(views, view)
+		}
+		render.JSON(w, r, ResponseUpcoming{Response: response.OK(), Birthdays: views})
+	}
+}
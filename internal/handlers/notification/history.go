@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/jwt"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+const (
+	defaultHistoryLimit = 100
+	maxHistoryLimit     = 1000
+)
+
+type History interface {
+	SearchAttempts(ctx context.Context, filter entities.AttemptFilter) ([]entities.NotificationAttempt, error)
+}
+
+type ResponseHistory struct {
+	response.Response
+	Notifications []entities.NotificationAttempt `json:"notifications"`
+}
+
+// MyNotifications lists the current user's notification history, newest first.
+func MyNotifications(log *slog.Logger, history History, userRepository jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.notification.myNotifications"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := jwt.CurrentUser(w, r, log, userRepository)
+		if !ok {
+			return
+		}
+		filter, ok := historyFilter(w, r)
+		if !ok {
+			return
+		}
+		filter.Recipient = user.Email
+		searchHistory(w, r, log, history, filter)
+	}
+}
+
+// SearchNotifications lets admins search the whole history by employee, recipient, status and date.
+func SearchNotifications(log *slog.Logger, history History) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.notification.searchNotifications"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		filter, ok := historyFilter(w, r)
+		if !ok {
+			return
+		}
+		filter.Recipient = r.URL.Query().Get("recipient")
+		searchHistory(w, r, log, history, filter)
+	}
+}
+
+func searchHistory(w http.ResponseWriter, r *http.Request, log *slog.Logger, history History, filter entities.AttemptFilter) {
+	attempts, err := history.SearchAttempts(r.Context(), filter)
+	if err != nil {
+		log.Error("failed to search notification history", errMsg.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, response.Error("failed to get notification history"))
+		return
+	}
+	render.JSON(w, r, ResponseHistory{Response: response.OK(), Notifications: attempts})
+}
+
+// historyFilter reads emp_id, status, from, to (inclusive dates) and limit from the query string.
+func historyFilter(w http.ResponseWriter, r *http.Request) (entities.AttemptFilter, bool) {
+	query := r.URL.Query()
+	filter := entities.AttemptFilter{Status: query.Get("status"), Limit: defaultHistoryLimit}
+	fail := func(message string) (entities.AttemptFilter, bool) {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, response.Error(message))
+		return entities.AttemptFilter{}, false
+	}
+
+	if value := query.Get("emp_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id < 1 {
+			return fail("invalid emp_id")
+		}
+		filter.EmployeeID = id
+	}
+	switch filter.Status {
+	case "", entities.AttemptQueued, entities.AttemptSent, entities.AttemptFailed, entities.AttemptBounced, entities.AttemptSkipped:
+	default:
+		return fail("status must be one of queued, sent, failed, bounced, skipped")
+	}
+	if value := query.Get("from"); value != "" {
+		from, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return fail("from must be in YYYY-MM-DD format")
+		}
+		filter.From = &from
+	}
+	if value := query.Get("to"); value != "" {
+		to, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return fail("to must be in YYYY-MM-DD format")
+		}
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+	if value := query.Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxHistoryLimit {
+			return fail("invalid limit")
+		}
+		filter.Limit = n
+	}
+	return filter, true
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+const (
+	defaultOutboxLimit = 100
+	maxOutboxLimit     = 1000
+)
+
+type Outbox interface {
+	ListOutbox(ctx context.Context, status string, limit int) ([]entities.OutboxMessage, error)
+	Resend(ctx context.Context, id int64) (bool, error)
+}
+
+type ResponseOutbox struct {
+	response.Response
+	Messages []entities.OutboxMessage `json:"messages"`
+}
+
+func ListOutbox(log *slog.Logger, outboxRepository Outbox) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.notification.listOutbox"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		limit := defaultOutboxLimit
+		if value := r.URL.Query().Get("limit"); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > maxOutboxLimit {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("invalid limit"))
+				return
+			}
+			limit = n
+		}
+
+		messages, err := outboxRepository.ListOutbox(r.Context(), r.URL.Query().Get("status"), limit)
+		if err != nil {
+			log.Error("failed to list outbox", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("failed to list outbox"))
+			return
+		}
+		render.JSON(w, r, ResponseOutbox{Response: response.OK(), Messages: messages})
+	}
+}
+
+func ResendOutboxMessage(log *slog.Logger, outboxRepository Outbox) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.notification.resendOutboxMessage"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid message ID"))
+			return
+		}
+		requeued, err := outboxRepository.Resend(r.Context(), id)
+		if err != nil {
+			log.Error("failed to resend message", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("failed to resend message"))
+			return
+		}
+		if !requeued {
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, response.Error("message not found or still pending"))
+			return
+		}
+		log.Info("outbox message requeued", slog.Int64("outbox_id", id))
+		render.JSON(w, r, response.OK())
+	}
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/internal/notification"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+const dateLayout = "2006-01-02"
+
+type ResponsePreview struct {
+	response.Response
+	Date     string                 `json:"date"`
+	Stats    notification.RunStats  `json:"stats"`
+	Messages []notification.Message `json:"messages"`
+}
+
+// Preview runs the notification job for the given date against a dry-run sink.
+func Preview(log *slog.Logger, notifier *notification.Notifier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.notification.preview"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		date := time.Now()
+		if value := r.URL.Query().Get("date"); value != "" {
+			parsed, err := time.Parse(dateLayout, value)
+			if err != nil {
+				log.Error("invalid preview date", errMsg.Err(err))
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("date must be in YYYY-MM-DD format"))
+				return
+			}
+			date = notification.OnDate(parsed, date)
+		}
+
+		sink := notification.NewDryRunSink()
+		stats := notifier.SendBirthdayNotifications(r.Context(), sink, date)
+		render.JSON(w, r, ResponsePreview{Response: response.OK(), Date: date.Format(dateLayout),
+			Stats: stats, Messages: sink.Messages()})
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"birthday-service/api/response"
 	"birthday-service/internal/entities"
 	errMsg "birthday-service/internal/err"
+	"birthday-service/jwt"
 	"context"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
@@ -14,22 +16,42 @@ import (
 )
 
 type Sub interface {
-	CreateSub(ctx context.Context, sub *entities.Subscription) error
-	DeleteSub(ctx context.Context, id int) error
+	CreateSub(ctx context.Context, sub *entities.Subscription) (bool, error)
+	CreateSubs(ctx context.Context, userID int, employeeIDs []int) (int, error)
+	DeleteUserSub(ctx context.Context, userID, id int) (bool, error)
+	DeleteSubAsAdmin(ctx context.Context, id int) (bool, error)
+	DeleteSubsByEmployees(ctx context.Context, userID int, employeeIDs []int) (int, error)
+	UpdateSubOptions(ctx context.Context, userID, id int, shiftToBusinessDay *bool, eventTypes []string, depth *int) (bool, error)
+	UpdateSubOptionsAsAdmin(ctx context.Context, id int, shiftToBusinessDay *bool, eventTypes []string, depth *int) (bool, error)
+	SetSubMutedUntil(ctx context.Context, userID, id int, until *time.Time) (bool, error)
+	SetSubMutedUntilAsAdmin(ctx context.Context, id int, until *time.Time) (bool, error)
 	GetSubs(ctx context.Context, EmployeeID int) ([]entities.User, error)
+	StreamUserSubs(ctx context.Context, userID int, fn func(entities.SubscriptionDetails) error) error
 }
 
 type RequestSub struct {
+	// UserID lets admins subscribe another user; everyone else always subscribes themselves.
 	UserID int `json:"user_id"`
 	EmpID  int `json:"emp_id"`
+	TeamID int `json:"team_id"`
+	// ReportsOf subscribes to every report of the employee down to Depth levels (0 means all).
+	ReportsOf int `json:"reports_of"`
+	Depth     int `json:"depth" validate:"min=0"`
+	// All subscribes to everyone in the company.
+	All bool `json:"all"`
+	// ShiftToBusinessDay sends reminders for weekends and holidays as of the preceding business day.
+	ShiftToBusinessDay bool `json:"shift_to_business_day"`
+	// EventTypes selects the events to be notified about; only birthdays by default.
+	EventTypes []string `json:"event_types" validate:"omitempty,dive,oneof=birthday work_anniversary custom"`
 }
 
 type ResponseSub struct {
 	response.Response
-	ID int `json:"id"`
+	ID      int  `json:"id"`
+	Created bool `json:"created"`
 }
 
-func New(log *slog.Logger, subsRepository Sub) http.HandlerFunc {
+func New(log *slog.Logger, subsRepository Sub, userRepository jwt.UserFinder) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const loggerOptions = "handlers.createSub.New"
 		log = log.With(
@@ -50,21 +72,65 @@ func New(log *slog.Logger, subsRepository Sub) http.HandlerFunc {
 			render.JSON(w, r, response.ValidationError(validateErr))
 			return
 		}
-		sub := entities.Subscription{UserID: req.UserID, EmployeeID: req.EmpID}
-		err = subsRepository.CreateSub(r.Context(), &sub)
+		if countTargets(req) != 1 {
+			log.Error("Invalid request: exactly one subscription target is required")
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("exactly one of emp_id, team_id, reports_of and all is required"))
+			return
+		}
+		userID, ok := subscriberID(w, r, log, userRepository, req.UserID)
+		if !ok {
+			return
+		}
+		sub := entities.Subscription{UserID: userID, EmployeeID: req.EmpID, TeamID: req.TeamID,
+			ReportsOf: req.ReportsOf, Depth: req.Depth, AllEmployees: req.All,
+			ShiftToBusinessDay: req.ShiftToBusinessDay, EventTypes: req.EventTypes}
+		created, err := subsRepository.CreateSub(r.Context(), &sub)
 		if err != nil {
 			log.Error("Failed to create subscription", errMsg.Err(err))
 			render.JSON(w, r, response.Error("Failed to create subscription"))
 			return
 		}
-		log.Info("user added")
-		responseOK(w, r, sub.ID)
+		log.Info("subscription saved", slog.Int("sub_id", sub.ID), slog.Bool("created", created))
+		responseOK(w, r, sub.ID, created)
+	}
+}
+
+// subscriberID returns the user whose subscriptions the request manages: the requested one for
+// admins and the current user for everyone else, who may not name another user.
+func subscriberID(w http.ResponseWriter, r *http.Request, log *slog.Logger, users jwt.UserFinder, requested int) (int, bool) {
+	if requested != 0 && jwt.IsAdmin(r.Context()) {
+		return requested, true
+	}
+	user, ok := jwt.CurrentUser(w, r, log, users)
+	if !ok {
+		return 0, false
+	}
+	if requested != 0 && requested != user.ID {
+		render.Status(r, http.StatusForbidden)
+		render.JSON(w, r, response.Error("only admins can manage other users' subscriptions"))
+		return 0, false
+	}
+	return user.ID, true
+}
+
+func countTargets(req RequestSub) int {
+	count := 0
+	for _, id := range []int{req.EmpID, req.TeamID, req.ReportsOf} {
+		if id != 0 {
+			count++
+		}
+	}
+	if req.All {
+		count++
 	}
+	return count
 }
 
-func responseOK(w http.ResponseWriter, r *http.Request, id int) {
+func responseOK(w http.ResponseWriter, r *http.Request, id int, created bool) {
 	render.JSON(w, r, ResponseSub{
 		response.OK(),
 		id,
+		created,
 	})
 }
@@ -3,6 +3,7 @@ package handlers
 import (
 	"birthday-service/api/response"
 	errMsg "birthday-service/internal/err"
+	"birthday-service/jwt"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
@@ -11,7 +12,7 @@ import (
 	"strconv"
 )
 
-func DeleteSub(log *slog.Logger, subRepo Sub) http.HandlerFunc {
+func DeleteSub(log *slog.Logger, subRepo Sub, userRepository jwt.UserFinder) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const loggerOptions = "handlers.subs.delete.New"
 		log := log.With(
@@ -26,14 +27,28 @@ func DeleteSub(log *slog.Logger, subRepo Sub) http.HandlerFunc {
 			return
 		}
 
-		err = subRepo.DeleteSub(r.Context(), id)
+		var found bool
+		if jwt.IsAdmin(r.Context()) {
+			found, err = subRepo.DeleteSubAsAdmin(r.Context(), id)
+		} else {
+			user, ok := jwt.CurrentUser(w, r, log, userRepository)
+			if !ok {
+				return
+			}
+			found, err = subRepo.DeleteUserSub(r.Context(), user.ID, id)
+		}
 		if err != nil {
 			log.Error("Failed to delete sub", errMsg.Err(err))
 			render.Status(r, http.StatusInternalServerError)
 			render.JSON(w, r, response.Error("Failed to delete sub"))
 			return
 		}
-		log.Info("subscriptions deleted")
-		render.Status(r, http.StatusNoContent)
+		if !found {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("subscription not found"))
+			return
+		}
+		log.Info("subscriptions deleted", slog.Int("sub_id", id))
+		render.JSON(w, r, response.OK())
 	}
 }
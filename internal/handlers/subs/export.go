@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/internal/export"
+	"birthday-service/jwt"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+func ExportUserSubs(log *slog.Logger, subRepo Sub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.subs.export"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid user ID"))
+			return
+		}
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = export.FormatJSON
+		}
+		if !export.Supported(format) {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("unsupported export format"))
+			return
+		}
+
+		w.Header().Set("Content-Type", export.ContentType(format))
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="subscriptions.%s"`, format))
+		writer, err := export.NewSubscriptionWriter(w, format, jwt.IsAdmin(r.Context()))
+		if err != nil {
+			log.Error("failed to start export", errMsg.Err(err))
+			return
+		}
+
+		err = subRepo.StreamUserSubs(r.Context(), userID, func(sub entities.SubscriptionDetails) error {
+			return writer.Write(sub)
+		})
+		if err == nil {
+			err = writer.Close()
+		}
+		if err != nil {
+			log.Error("failed to export subscriptions", errMsg.Err(err))
+			return
+		}
+		log.Info("subscriptions exported", slog.Int("user_id", userID), slog.String("format", format))
+	}
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/internal/privacy"
+	"birthday-service/jwt"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+)
+
+type ResponseSubs struct {
+	response.Response
+	Subscriptions []entities.SubscriptionDetails `json:"subscriptions"`
+}
+
+type SubscriberView struct {
+	ID    int    `json:"user_id"`
+	Email string `json:"email"`
+}
+
+type ResponseSubscribers struct {
+	response.Response
+	Subscribers []SubscriberView `json:"subscribers"`
+}
+
+type RequestBulkSubs struct {
+	// UserID may only be set by admins; everyone else manages their own subscriptions.
+	UserID int   `json:"user_id"`
+	EmpIDs []int `json:"emp_ids" validate:"required,min=1,max=1000"`
+}
+
+type ResponseBulkSubs struct {
+	response.Response
+	Requested int `json:"requested"`
+	Changed   int `json:"changed"`
+}
+
+// ListMySubs returns the current user's subscriptions with employee details.
+func ListMySubs(log *slog.Logger, subRepo Sub, userRepository jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.subs.listMine"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := jwt.CurrentUser(w, r, log, userRepository)
+		if !ok {
+			return
+		}
+
+		admin := jwt.IsAdmin(r.Context())
+		subs := []entities.SubscriptionDetails{}
+		err := subRepo.StreamUserSubs(r.Context(), user.ID, func(sub entities.SubscriptionDetails) error {
+			subs = append(subs, privacy.SubscriptionView(sub, admin))
+			return nil
+		})
+		if err != nil {
+			log.Error("Failed to list subscriptions", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to list subscriptions"))
+			return
+		}
+		render.JSON(w, r, ResponseSubs{Response: response.OK(), Subscriptions: subs})
+	}
+}
+
+// ListEmployeeSubscribers returns every user notified about the employee's birthday.
+func ListEmployeeSubscribers(log *slog.Logger, subRepo Sub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.subs.listSubscribers"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid emp ID"))
+			return
+		}
+		users, err := subRepo.GetSubs(r.Context(), id)
+		if err != nil {
+			log.Error("Failed to get subscribers", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to get subscribers"))
+			return
+		}
+		subscribers := make([]SubscriberView, 0, len(users))
+		for _, user := range users {
+			subscribers = append(subscribers, SubscriberView{ID: user.ID, Email: user.Email})
+		}
+		render.JSON(w, r, ResponseSubscribers{Response: response.OK(), Subscribers: subscribers})
+	}
+}
+
+func BulkSubscribe(log *slog.Logger, subRepo Sub, userRepository jwt.UserFinder) http.HandlerFunc {
+	return bulkHandler(log, "handlers.subs.bulkSubscribe", userRepository, subRepo.CreateSubs)
+}
+
+func BulkUnsubscribe(log *slog.Logger, subRepo Sub, userRepository jwt.UserFinder) http.HandlerFunc {
+	return bulkHandler(log, "handlers.subs.bulkUnsubscribe", userRepository, subRepo.DeleteSubsByEmployees)
+}
+
+func bulkHandler(log *slog.Logger, loggerOptions string, userRepository jwt.UserFinder,
+	apply func(ctx context.Context, userID int, employeeIDs []int) (int, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		var req RequestBulkSubs
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", errMsg.Err(err))
+			render.JSON(w, r, response.Error("failed to decode request"))
+			return
+		}
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("Invalid request", errMsg.Err(err))
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		userID, ok := subscriberID(w, r, log, userRepository, req.UserID)
+		if !ok {
+			return
+		}
+
+		changed, err := apply(r.Context(), userID, req.EmpIDs)
+		if err != nil {
+			log.Error("Failed to update subscriptions", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to update subscriptions"))
+			return
+		}
+		log.Info("subscriptions updated in bulk", slog.Int("user_id", userID), slog.Int("changed", changed))
+		render.JSON(w, r, ResponseBulkSubs{Response: response.OK(), Requested: len(req.EmpIDs), Changed: changed})
+	}
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/jwt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type RequestMuteSub struct {
+	Until time.Time `json:"until"`
+}
+
+// MuteSub pauses one of the current user's subscriptions; admins may mute any subscription.
+func MuteSub(log *slog.Logger, subRepo Sub, userRepository jwt.UserFinder) http.HandlerFunc {
+	return muteHandler(log, "handlers.subs.mute", subRepo, userRepository, true)
+}
+
+func UnmuteSub(log *slog.Logger, subRepo Sub, userRepository jwt.UserFinder) http.HandlerFunc {
+	return muteHandler(log, "handlers.subs.unmute", subRepo, userRepository, false)
+}
+
+func muteHandler(log *slog.Logger, loggerOptions string, subRepo Sub, userRepository jwt.UserFinder, mute bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid sub ID"))
+			return
+		}
+
+		var until *time.Time
+		if mute {
+			var req RequestMuteSub
+			if err := render.DecodeJSON(r.Body, &req); err != nil {
+				log.Error("failed to decode request body", errMsg.Err(err))
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("failed to decode request"))
+				return
+			}
+			if !req.Until.After(time.Now()) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("until must be in the future"))
+				return
+			}
+			until = &req.Until
+		}
+
+		var found bool
+		if jwt.IsAdmin(r.Context()) {
+			found, err = subRepo.SetSubMutedUntilAsAdmin(r.Context(), id, until)
+		} else {
+			user, ok := jwt.CurrentUser(w, r, log, userRepository)
+			if !ok {
+				return
+			}
+			found, err = subRepo.SetSubMutedUntil(r.Context(), user.ID, id, until)
+		}
+		if err != nil {
+			log.Error("Failed to update subscription mute", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to update subscription"))
+			return
+		}
+		if !found {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("subscription not found"))
+			return
+		}
+		log.Info("subscription mute updated", slog.Int("sub_id", id), slog.Bool("muted", mute))
+		render.JSON(w, r, response.OK())
+	}
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/jwt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+)
+
+// RequestSubOptions changes only the options that are present in the request.
+type RequestSubOptions struct {
+	ShiftToBusinessDay *bool    `json:"shift_to_business_day"`
+	EventTypes         []string `json:"event_types" validate:"omitempty,min=1,dive,oneof=birthday work_anniversary custom"`
+	// Depth only applies to reports_of subscriptions; 0 means all levels.
+	Depth *int `json:"depth" validate:"omitempty,min=0"`
+}
+
+// UpdateSubOptions changes the reminder options of one of the current user's subscriptions;
+// admins may change any subscription.
+func UpdateSubOptions(log *slog.Logger, subRepo Sub, userRepository jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.subs.options"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid sub ID"))
+			return
+		}
+
+		var req RequestSubOptions
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", errMsg.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("failed to decode request"))
+			return
+		}
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("Invalid request", errMsg.Err(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+		if req.ShiftToBusinessDay == nil && req.EventTypes == nil && req.Depth == nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("nothing to update"))
+			return
+		}
+
+		var found bool
+		if jwt.IsAdmin(r.Context()) {
+			found, err = subRepo.UpdateSubOptionsAsAdmin(r.Context(), id, req.ShiftToBusinessDay, req.EventTypes, req.Depth)
+		} else {
+			user, ok := jwt.CurrentUser(w, r, log, userRepository)
+			if !ok {
+				return
+			}
+			found, err = subRepo.UpdateSubOptions(r.Context(), user.ID, id, req.ShiftToBusinessDay, req.EventTypes, req.Depth)
+		}
+		if err != nil {
+			log.Error("Failed to update subscription options", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to update subscription"))
+			return
+		}
+		if !found {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("subscription not found"))
+			return
+		}
+		log.Info("subscription options updated", slog.Int("sub_id", id))
+		render.JSON(w, r, response.OK())
+	}
+}
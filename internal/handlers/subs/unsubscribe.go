@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/internal/unsubscribe"
+	"context"
+	"html/template"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type UserSubs interface {
+	DeleteUserSub(ctx context.Context, userID, id int) (bool, error)
+}
+
+type UserOptOut interface {
+	SetUnsubscribedAll(ctx context.Context, userID int, unsubscribed bool) (bool, error)
+}
+
+// confirmPage is shown for GET so that link scanners in mail clients do not unsubscribe anyone;
+// the button posts the same token back.
+var confirmPage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Unsubscribe</title></head>
+<body>
+<p>{{if .All}}Stop all birthday notifications?{{else}}Cancel this birthday subscription?{{end}}</p>
+<form method="post" action="/unsubscribe?token={{.Token}}"><button type="submit">Unsubscribe</button></form>
+</body></html>`))
+
+func UnsubscribePage(log *slog.Logger, links *unsubscribe.Links) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.subs.unsubscribePage"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		token := r.URL.Query().Get("token")
+		_, subscriptionID, err := links.Parse(token)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error(err.Error()))
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		data := struct {
+			All   bool
+			Token string
+		}{subscriptionID == unsubscribe.AllSubscriptions, token}
+		if err := confirmPage.Execute(w, data); err != nil {
+			log.Error("failed to render unsubscribe page", errMsg.Err(err))
+		}
+	}
+}
+
+// Unsubscribe handles both the confirmation form and RFC 8058 one-click POSTs from mail clients.
+func Unsubscribe(log *slog.Logger, links *unsubscribe.Links, subRepository UserSubs, userRepository UserOptOut) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.subs.unsubscribe"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		token := r.URL.Query().Get("token")
+		if token == "" {
+			token = r.PostFormValue("token")
+		}
+		userID, subscriptionID, err := links.Parse(token)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error(err.Error()))
+			return
+		}
+
+		var found bool
+		if subscriptionID == unsubscribe.AllSubscriptions {
+			found, err = userRepository.SetUnsubscribedAll(r.Context(), userID, true)
+		} else {
+			found, err = subRepository.DeleteUserSub(r.Context(), userID, subscriptionID)
+		}
+		if err != nil {
+			log.Error("Failed to unsubscribe", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to unsubscribe"))
+			return
+		}
+		// An already removed subscription is not an error: the link may be clicked twice.
+		log.Info("unsubscribed", slog.Int("user_id", userID), slog.Int("sub_id", subscriptionID), slog.Bool("found", found))
+		render.JSON(w, r, response.OK())
+	}
+}
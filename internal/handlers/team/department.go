@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+)
+
+type RequestDepartment struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type ResponseDepartment struct {
+	response.Response
+	Department entities.Department `json:"department"`
+}
+
+type ResponseDepartmentList struct {
+	response.Response
+	Departments []entities.Department `json:"departments"`
+}
+
+func NewDepartment(log *slog.Logger, teamRepository Team) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.department.New"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		var req RequestDepartment
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", errMsg.Err(err))
+			render.JSON(w, r, response.Error("failed to decode request"))
+			return
+		}
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("Invalid request", errMsg.Err(err))
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+		department := entities.Department{Name: req.Name}
+		err = teamRepository.CreateDepartment(r.Context(), &department)
+		if err != nil {
+			log.Error("Failed to create department", errMsg.Err(err))
+			render.JSON(w, r, response.Error("Failed to create department"))
+			return
+		}
+		log.Info("department added", slog.Int("department_id", department.ID))
+		render.JSON(w, r, ResponseDepartment{Response: response.OK(), Department: department})
+	}
+}
+
+func ListDepartments(log *slog.Logger, teamRepository Team) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.department.List"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		departments, err := teamRepository.GetAllDepartments(r.Context())
+		if err != nil {
+			log.Error("Failed to retrieve departments", errMsg.Err(err))
+			render.JSON(w, r, response.Error("Failed to retrieve departments"))
+			return
+		}
+		render.JSON(w, r, ResponseDepartmentList{Response: response.OK(), Departments: departments})
+	}
+}
+
+func DeleteDepartment(log *slog.Logger, teamRepository Team) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.department.Delete"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid department ID"))
+			return
+		}
+
+		err = teamRepository.DeleteDepartment(r.Context(), id)
+		if err != nil {
+			log.Error("Failed to delete department", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to delete department"))
+			return
+		}
+		log.Info("department deleted")
+		render.JSON(w, r, response.OK())
+	}
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/internal/privacy"
+	"birthday-service/jwt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+)
+
+type RequestMember struct {
+	EmpID int `json:"emp_id" validate:"required"`
+}
+
+type ResponseMembers struct {
+	response.Response
+	Employees []privacy.EmployeeView `json:"employees"`
+}
+
+func AddMember(log *slog.Logger, teamRepository Team) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.team.AddMember"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		teamID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid team ID"))
+			return
+		}
+		var req RequestMember
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", errMsg.Err(err))
+			render.JSON(w, r, response.Error("failed to decode request"))
+			return
+		}
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("Invalid request", errMsg.Err(err))
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		err = teamRepository.AddMember(r.Context(), teamID, req.EmpID)
+		if err != nil {
+			log.Error("Failed to add team member", errMsg.Err(err))
+			render.JSON(w, r, response.Error("Failed to add team member"))
+			return
+		}
+		log.Info("team member added", slog.Int("team_id", teamID), slog.Int("emp_id", req.EmpID))
+		render.JSON(w, r, response.OK())
+	}
+}
+
+func RemoveMember(log *slog.Logger, teamRepository Team) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.team.RemoveMember"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		teamID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid team ID"))
+			return
+		}
+		empID, err := strconv.Atoi(chi.URLParam(r, "empId"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid emp ID"))
+			return
+		}
+
+		err = teamRepository.RemoveMember(r.Context(), teamID, empID)
+		if err != nil {
+			log.Error("Failed to remove team member", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to remove team member"))
+			return
+		}
+		log.Info("team member removed", slog.Int("team_id", teamID), slog.Int("emp_id", empID))
+		render.JSON(w, r, response.OK())
+	}
+}
+
+func ListMembers(log *slog.Logger, teamRepository Team) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.team.ListMembers"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		teamID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid team ID"))
+			return
+		}
+
+		employees, err := teamRepository.GetMembers(r.Context(), teamID)
+		if err != nil {
+			log.Error("Failed to retrieve team members", errMsg.Err(err))
+			render.JSON(w, r, response.Error("Failed to retrieve team members"))
+			return
+		}
+		render.JSON(w, r, ResponseMembers{Response: response.OK(), Employees: privacy.Views(employees, jwt.IsAdmin(r.Context()))})
+	}
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+)
+
+type Team interface {
+	CreateDepartment(ctx context.Context, department *entities.Department) error
+	GetAllDepartments(ctx context.Context) ([]entities.Department, error)
+	DeleteDepartment(ctx context.Context, id int) error
+	CreateTeam(ctx context.Context, team *entities.Team) error
+	GetAllTeams(ctx context.Context, departmentID int) ([]entities.Team, error)
+	DeleteTeam(ctx context.Context, id int) error
+	AddMember(ctx context.Context, teamID, empID int) error
+	RemoveMember(ctx context.Context, teamID, empID int) error
+	GetMembers(ctx context.Context, teamID int) ([]entities.Employee, error)
+}
+
+type RequestTeam struct {
+	Name         string `json:"name" validate:"required"`
+	DepartmentID int    `json:"department_id"`
+}
+
+type ResponseTeam struct {
+	response.Response
+	Team entities.Team `json:"team"`
+}
+
+type ResponseTeamList struct {
+	response.Response
+	Teams []entities.Team `json:"teams"`
+}
+
+func NewTeam(log *slog.Logger, teamRepository Team) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.team.New"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		var req RequestTeam
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", errMsg.Err(err))
+			render.JSON(w, r, response.Error("failed to decode request"))
+			return
+		}
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("Invalid request", errMsg.Err(err))
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+		team := entities.Team{Name: req.Name, DepartmentID: req.DepartmentID}
+		err = teamRepository.CreateTeam(r.Context(), &team)
+		if err != nil {
+			log.Error("Failed to create team", errMsg.Err(err))
+			render.JSON(w, r, response.Error("Failed to create team"))
+			return
+		}
+		log.Info("team added", slog.Int("team_id", team.ID))
+		render.JSON(w, r, ResponseTeam{Response: response.OK(), Team: team})
+	}
+}
+
+func ListTeams(log *slog.Logger, teamRepository Team) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.team.List"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		departmentID := 0
+		if idStr := r.URL.Query().Get("department_id"); idStr != "" {
+			id, err := strconv.Atoi(idStr)
+			if err != nil {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("Invalid department ID"))
+				return
+			}
+			departmentID = id
+		}
+
+		teams, err := teamRepository.GetAllTeams(r.Context(), departmentID)
+		if err != nil {
+			log.Error("Failed to retrieve teams", errMsg.Err(err))
+			render.JSON(w, r, response.Error("Failed to retrieve teams"))
+			return
+		}
+		render.JSON(w, r, ResponseTeamList{Response: response.OK(), Teams: teams})
+	}
+}
+
+func DeleteTeam(log *slog.Logger, teamRepository Team) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.team.Delete"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid team ID"))
+			return
+		}
+
+		err = teamRepository.DeleteTeam(r.Context(), id)
+		if err != nil {
+			log.Error("Failed to delete team", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to delete team"))
+			return
+		}
+		log.Info("team deleted")
+		render.JSON(w, r, response.OK())
+	}
+}
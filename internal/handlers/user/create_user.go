@@ -8,6 +8,7 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
@@ -20,6 +21,11 @@ type User interface {
 	FindUserById(ctx context.Context, id int) (entities.User, error)
 	DeleteUserById(ctx context.Context, id int) error
 	UpdateUser(ctx context.Context, user *entities.User) error
+	LinkEmployee(ctx context.Context, userID, employeeID int) error
+	LinkEmployeesByEmail(ctx context.Context) (int, error)
+	UpdateNotificationSettings(ctx context.Context, user *entities.User) error
+	SetMutedUntil(ctx context.Context, userID int, until *time.Time) error
+	SetQuietHours(ctx context.Context, userID int, start, end *int) error
 }
 
 type RequestUser struct {
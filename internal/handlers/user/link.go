@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	errMsg "birthday-service/internal/err"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type RequestLinkEmployee struct {
+	EmployeeID int `json:"employee_id"`
+}
+
+type ResponseLinked struct {
+	response.Response
+	Linked int `json:"linked"`
+}
+
+func LinkEmployeeHandler(log *slog.Logger, userRepo User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.user.LinkEmployee"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("Invalid user ID"))
+			return
+		}
+		var req RequestLinkEmployee
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", errMsg.Err(err))
+			render.JSON(w, r, response.Error("failed to decode request"))
+			return
+		}
+
+		err = userRepo.LinkEmployee(r.Context(), userID, req.EmployeeID)
+		if err != nil {
+			log.Error("Failed to link employee", errMsg.Err(err))
+			render.JSON(w, r, response.Error("Failed to link employee"))
+			return
+		}
+		log.Info("employee linked", slog.Int("user_id", userID), slog.Int("emp_id", req.EmployeeID))
+		render.JSON(w, r, response.OK())
+	}
+}
+
+func LinkByEmailHandler(log *slog.Logger, userRepo User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.user.LinkByEmail"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		linked, err := userRepo.LinkEmployeesByEmail(r.Context())
+		if err != nil {
+			log.Error("Failed to link employees by email", errMsg.Err(err))
+			render.JSON(w, r, response.Error("Failed to link employees by email"))
+			return
+		}
+		log.Info("employees linked by email", slog.Int("linked", linked))
+		render.JSON(w, r, ResponseLinked{Response: response.OK(), Linked: linked})
+	}
+}
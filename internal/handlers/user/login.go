@@ -21,7 +21,7 @@ type ResponseAuthUser struct {
 	Token string `json:"token"`
 }
 
-func LoginFunc(log *slog.Logger, userRepository User, jwt *jwt.JWTManager) http.HandlerFunc {
+func LoginFunc(log *slog.Logger, userRepository User, jwtManager *jwt.JWTManager) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log = log.With(
 			slog.String("request_id", middleware.GetReqID(r.Context())),
@@ -54,7 +54,11 @@ func LoginFunc(log *slog.Logger, userRepository User, jwt *jwt.JWTManager) http.
 			render.JSON(w, r, response.Error("Invalid password"))
 			return
 		}
-		token, err := jwt.GenerateToken(user.Email, time.Second*600)
+		role := jwt.RoleUser
+		if user.IsAdmin {
+			role = jwt.RoleAdmin
+		}
+		token, err := jwtManager.GenerateToken(user.Email, role, time.Second*600)
 		if err != nil {
 			log.Error("failed to authoriza")
 			return
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/jwt"
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+)
+
+type EmployeeRecords interface {
+	FindEmployeeById(ctx context.Context, id int) (entities.Employee, error)
+	UpdateEmployee(ctx context.Context, employee *entities.Employee) error
+}
+
+type ResponseMe struct {
+	response.Response
+	ID           int                `json:"user_id"`
+	Email        string             `json:"email"`
+	IsAdmin      bool               `json:"is_admin"`
+	Digest       string             `json:"digest_mode"`
+	Unsubscribed bool               `json:"unsubscribed_all"`
+	MutedUntil   *time.Time         `json:"muted_until,omitempty"`
+	QuietStart   *int               `json:"quiet_hours_start,omitempty"`
+	QuietEnd     *int               `json:"quiet_hours_end,omitempty"`
+	Timezone     string             `json:"timezone"`
+	DeliveryHour *int               `json:"delivery_hour,omitempty"`
+	Employee     *entities.Employee `json:"employee,omitempty"`
+}
+
+type RequestUpdateMyEmployee struct {
+	Name     string `json:"name" validate:"required"`
+	Birthday string `json:"birthday" validate:"required"`
+}
+
+const birthdayFormat = "02.01.2006"
+
+func Me(log *slog.Logger, userRepository User, empRepository EmployeeRecords) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.user.Me"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := jwt.CurrentUser(w, r, log, userRepository)
+		if !ok {
+			return
+		}
+
+		resp := ResponseMe{Response: response.OK(), ID: user.ID, Email: user.Email, IsAdmin: user.IsAdmin,
+			Digest: user.DigestMode, Unsubscribed: user.UnsubscribedAll, MutedUntil: user.MutedUntil,
+			QuietStart: user.QuietHoursStart, QuietEnd: user.QuietHoursEnd, Timezone: user.Timezone,
+			DeliveryHour: user.DeliveryHour}
+		if user.EmployeeID != 0 {
+			employee, err := empRepository.FindEmployeeById(r.Context(), user.EmployeeID)
+			if err != nil {
+				log.Error("Failed to find linked employee", errMsg.Err(err))
+				render.JSON(w, r, response.Error("Failed to find linked employee"))
+				return
+			}
+			resp.Employee = &employee
+		}
+		render.JSON(w, r, resp)
+	}
+}
+
+func UpdateMyEmployee(log *slog.Logger, userRepository User, empRepository EmployeeRecords) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.user.UpdateMyEmployee"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := jwt.CurrentUser(w, r, log, userRepository)
+		if !ok {
+			return
+		}
+		if user.EmployeeID == 0 {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("user is not linked to an employee"))
+			return
+		}
+
+		var req RequestUpdateMyEmployee
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", errMsg.Err(err))
+			render.JSON(w, r, response.Error("failed to decode request"))
+			return
+		}
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("Invalid request", errMsg.Err(err))
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+		birthday, err := time.Parse(birthdayFormat, req.Birthday)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("birthday must be in format "+birthdayFormat))
+			return
+		}
+
+		employee := entities.Employee{ID: user.EmployeeID, Name: req.Name, Birthday: birthday}
+		err = empRepository.UpdateEmployee(r.Context(), &employee)
+		if err != nil {
+			log.Error("Failed to update employee", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to update employee"))
+			return
+		}
+		log.Info("employee updated by linked user", slog.Int("emp_id", employee.ID))
+		render.JSON(w, r, response.OK())
+	}
+}
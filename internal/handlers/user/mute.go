@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/jwt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+)
+
+type RequestMute struct {
+	Until time.Time `json:"until" validate:"required"`
+}
+
+type RequestQuietHours struct {
+	Start *int `json:"start" validate:"required,min=0,max=23"`
+	End   *int `json:"end" validate:"required,min=0,max=23"`
+}
+
+// MuteMe pauses all of the current user's notifications until the requested time.
+func MuteMe(log *slog.Logger, userRepository User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.user.MuteMe"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		var req RequestMute
+		if !decodeAndValidate(w, r, log, &req) {
+			return
+		}
+		if !req.Until.After(time.Now()) {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.Error("until must be in the future"))
+			return
+		}
+		updateCurrentUser(w, r, log, userRepository, "mute", func(userID int) error {
+			return userRepository.SetMutedUntil(r.Context(), userID, &req.Until)
+		})
+	}
+}
+
+func UnmuteMe(log *slog.Logger, userRepository User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.user.UnmuteMe"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		updateCurrentUser(w, r, log, userRepository, "unmute", func(userID int) error {
+			return userRepository.SetMutedUntil(r.Context(), userID, nil)
+		})
+	}
+}
+
+func SetQuietHours(log *slog.Logger, userRepository User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.user.SetQuietHours"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		var req RequestQuietHours
+		if !decodeAndValidate(w, r, log, &req) {
+			return
+		}
+		updateCurrentUser(w, r, log, userRepository, "set quiet hours", func(userID int) error {
+			return userRepository.SetQuietHours(r.Context(), userID, req.Start, req.End)
+		})
+	}
+}
+
+func ClearQuietHours(log *slog.Logger, userRepository User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.user.ClearQuietHours"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		updateCurrentUser(w, r, log, userRepository, "clear quiet hours", func(userID int) error {
+			return userRepository.SetQuietHours(r.Context(), userID, nil, nil)
+		})
+	}
+}
+
+func decodeAndValidate(w http.ResponseWriter, r *http.Request, log *slog.Logger, req any) bool {
+	if err := render.DecodeJSON(r.Body, req); err != nil {
+		log.Error("failed to decode request body", errMsg.Err(err))
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, response.Error("failed to decode request"))
+		return false
+	}
+	if err := validator.New().Struct(req); err != nil {
+		validateErr := err.(validator.ValidationErrors)
+		log.Error("Invalid request", errMsg.Err(err))
+		render.JSON(w, r, response.ValidationError(validateErr))
+		return false
+	}
+	return true
+}
+
+func updateCurrentUser(w http.ResponseWriter, r *http.Request, log *slog.Logger, userRepository User, action string, update func(userID int) error) {
+	user, ok := jwt.CurrentUser(w, r, log, userRepository)
+	if !ok {
+		return
+	}
+	if err := update(user.ID); err != nil {
+		log.Error("Failed to "+action, errMsg.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, response.Error("Failed to "+action))
+		return
+	}
+	log.Info("notification settings updated", slog.Int("user_id", user.ID), slog.String("action", action))
+	render.JSON(w, r, response.OK())
+}
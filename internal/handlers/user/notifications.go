@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/internal/schedule"
+	"birthday-service/jwt"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+)
+
+// RequestNotificationSettings changes only the fields that are present.
+type RequestNotificationSettings struct {
+	DigestMode      string `json:"digest_mode" validate:"omitempty,oneof=immediate daily weekly"`
+	UnsubscribedAll *bool  `json:"unsubscribed_all"`
+	Timezone        string `json:"timezone"`
+	// DeliveryHour is the local hour from which mail is sent; -1 lets it go out at any time.
+	DeliveryHour *int `json:"delivery_hour" validate:"omitempty,min=-1,max=23"`
+}
+
+func UpdateNotificationSettings(log *slog.Logger, userRepository User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.user.UpdateNotificationSettings"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := jwt.CurrentUser(w, r, log, userRepository)
+		if !ok {
+			return
+		}
+
+		var req RequestNotificationSettings
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", errMsg.Err(err))
+			render.JSON(w, r, response.Error("failed to decode request"))
+			return
+		}
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("Invalid request", errMsg.Err(err))
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		if req.Timezone != "" {
+			if _, err := schedule.LoadLocation(req.Timezone); err != nil {
+				log.Error("Invalid timezone", slog.String("timezone", req.Timezone), errMsg.Err(err))
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.Error("unknown timezone"))
+				return
+			}
+			user.Timezone = req.Timezone
+		}
+		if req.DeliveryHour != nil {
+			user.DeliveryHour = req.DeliveryHour
+			if *req.DeliveryHour < 0 {
+				user.DeliveryHour = nil
+			}
+		}
+		if req.DigestMode != "" {
+			user.DigestMode = req.DigestMode
+		}
+		if req.UnsubscribedAll != nil {
+			user.UnsubscribedAll = *req.UnsubscribedAll
+		}
+		if err := userRepository.UpdateNotificationSettings(r.Context(), &user); err != nil {
+			log.Error("Failed to update notification settings", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to update notification settings"))
+			return
+		}
+		log.Info("notification settings updated", slog.Int("user_id", user.ID), slog.String("digest_mode", user.DigestMode),
+			slog.Bool("unsubscribed_all", user.UnsubscribedAll), slog.String("timezone", user.Timezone))
+		render.JSON(w, r, response.OK())
+	}
+}
@@ -0,0 +1,374 @@
+package handlers
+
+import (
+	"birthday-service/api/response"
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/jwt"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+)
+
+type Wishlist interface {
+	CreateItem(ctx context.Context, item *entities.WishlistItem) error
+	UpdateItem(ctx context.Context, item *entities.WishlistItem) (bool, error)
+	DeleteItem(ctx context.Context, empID, id int) (bool, error)
+	GetWishlist(ctx context.Context, empID int) ([]entities.WishlistItem, error)
+	ReserveItem(ctx context.Context, empID, id, userID int) (bool, error)
+	CancelReservation(ctx context.Context, empID, id, userID int) (bool, error)
+}
+
+type Employees interface {
+	FindEmployeeById(ctx context.Context, id int) (entities.Employee, error)
+}
+
+type Subscribers interface {
+	IsSubscribed(ctx context.Context, userID, employeeID int) (bool, error)
+}
+
+type RequestItem struct {
+	Title    string `json:"title" validate:"required,max=255"`
+	URL      string `json:"url" validate:"omitempty,url"`
+	PriceMin *int   `json:"price_min" validate:"omitempty,min=0"`
+	PriceMax *int   `json:"price_max" validate:"omitempty,min=0"`
+}
+
+type ResponseItem struct {
+	response.Response
+	Item entities.WishlistItem `json:"item"`
+}
+
+type ResponseWishlist struct {
+	response.Response
+	EmployeeID int                     `json:"emp_id"`
+	Items      []entities.WishlistItem `json:"items"`
+}
+
+// MyWishlist shows the current user's own wishlist without reservations.
+func MyWishlist(log *slog.Logger, wishlistRepo Wishlist, userRepo jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.wishlist.mine"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := linkedUser(w, r, log, userRepo)
+		if !ok {
+			return
+		}
+		renderWishlist(w, r, log, wishlistRepo, user, user.EmployeeID)
+	}
+}
+
+func AddItem(log *slog.Logger, wishlistRepo Wishlist, userRepo jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.wishlist.add"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := linkedUser(w, r, log, userRepo)
+		if !ok {
+			return
+		}
+		var req RequestItem
+		if !decodeItem(w, r, log, &req) {
+			return
+		}
+		item := entities.WishlistItem{EmployeeID: user.EmployeeID, Title: req.Title, URL: req.URL,
+			PriceMin: req.PriceMin, PriceMax: req.PriceMax}
+		if err := wishlistRepo.CreateItem(r.Context(), &item); err != nil {
+			log.Error("Failed to add wishlist item", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to add wishlist item"))
+			return
+		}
+		log.Info("wishlist item added", slog.Int("emp_id", item.EmployeeID), slog.Int("item_id", item.ID))
+		render.JSON(w, r, ResponseItem{Response: response.OK(), Item: item})
+	}
+}
+
+func UpdateItem(log *slog.Logger, wishlistRepo Wishlist, userRepo jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.wishlist.update"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := linkedUser(w, r, log, userRepo)
+		if !ok {
+			return
+		}
+		id, ok := itemID(w, r)
+		if !ok {
+			return
+		}
+		var req RequestItem
+		if !decodeItem(w, r, log, &req) {
+			return
+		}
+		item := entities.WishlistItem{ID: id, EmployeeID: user.EmployeeID, Title: req.Title, URL: req.URL,
+			PriceMin: req.PriceMin, PriceMax: req.PriceMax}
+		found, err := wishlistRepo.UpdateItem(r.Context(), &item)
+		if err != nil {
+			log.Error("Failed to update wishlist item", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to update wishlist item"))
+			return
+		}
+		if !found {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("wishlist item not found"))
+			return
+		}
+		render.JSON(w, r, ResponseItem{Response: response.OK(), Item: item})
+	}
+}
+
+func DeleteItem(log *slog.Logger, wishlistRepo Wishlist, userRepo jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.wishlist.delete"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := linkedUser(w, r, log, userRepo)
+		if !ok {
+			return
+		}
+		id, ok := itemID(w, r)
+		if !ok {
+			return
+		}
+		deleted, err := wishlistRepo.DeleteItem(r.Context(), user.EmployeeID, id)
+		if err != nil {
+			log.Error("Failed to delete wishlist item", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to delete wishlist item"))
+			return
+		}
+		if !deleted {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("wishlist item not found"))
+			return
+		}
+		render.JSON(w, r, response.OK())
+	}
+}
+
+// EmployeeWishlist shows an employee's wishlist to their subscribers and admins. Items reserved
+// by someone are marked as such without saying by whom.
+func EmployeeWishlist(log *slog.Logger, wishlistRepo Wishlist, empRepo Employees, subsRepo Subscribers,
+	userRepo jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.wishlist.employee"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, employee, ok := visibleWishlist(w, r, log, empRepo, subsRepo, userRepo)
+		if !ok {
+			return
+		}
+		renderWishlist(w, r, log, wishlistRepo, user, employee.ID)
+	}
+}
+
+func ReserveItem(log *slog.Logger, wishlistRepo Wishlist, empRepo Employees, subsRepo Subscribers,
+	userRepo jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.wishlist.reserve"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, employee, ok := visibleWishlist(w, r, log, empRepo, subsRepo, userRepo)
+		if !ok {
+			return
+		}
+		if user.EmployeeID == employee.ID {
+			render.Status(r, http.StatusForbidden)
+			render.JSON(w, r, response.Error("you cannot reserve items on your own wishlist"))
+			return
+		}
+		id, ok := itemID(w, r)
+		if !ok {
+			return
+		}
+		items, err := wishlistRepo.GetWishlist(r.Context(), employee.ID)
+		if err != nil {
+			log.Error("Failed to get wishlist", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to reserve wishlist item"))
+			return
+		}
+		if !containsItem(items, id) {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("wishlist item not found"))
+			return
+		}
+		reserved, err := wishlistRepo.ReserveItem(r.Context(), employee.ID, id, user.ID)
+		if err != nil {
+			log.Error("Failed to reserve wishlist item", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to reserve wishlist item"))
+			return
+		}
+		if !reserved {
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, response.Error("the item is already reserved"))
+			return
+		}
+		log.Info("wishlist item reserved", slog.Int("emp_id", employee.ID), slog.Int("item_id", id))
+		render.JSON(w, r, response.OK())
+	}
+}
+
+func CancelReservation(log *slog.Logger, wishlistRepo Wishlist, empRepo Employees, subsRepo Subscribers,
+	userRepo jwt.UserFinder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const loggerOptions = "handlers.wishlist.cancelReservation"
+		log := log.With(
+			slog.String("options", loggerOptions),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, employee, ok := visibleWishlist(w, r, log, empRepo, subsRepo, userRepo)
+		if !ok {
+			return
+		}
+		id, ok := itemID(w, r)
+		if !ok {
+			return
+		}
+		cancelled, err := wishlistRepo.CancelReservation(r.Context(), employee.ID, id, user.ID)
+		if err != nil {
+			log.Error("Failed to cancel reservation", errMsg.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to cancel reservation"))
+			return
+		}
+		if !cancelled {
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.Error("reservation not found"))
+			return
+		}
+		render.JSON(w, r, response.OK())
+	}
+}
+
+// renderWishlist hides reservations from the wishlist's owner and reveals to everyone else only
+// whether an item is taken and whether they took it.
+func renderWishlist(w http.ResponseWriter, r *http.Request, log *slog.Logger, wishlistRepo Wishlist, user entities.User, empID int) {
+	items, err := wishlistRepo.GetWishlist(r.Context(), empID)
+	if err != nil {
+		log.Error("Failed to get wishlist", errMsg.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, response.Error("Failed to get wishlist"))
+		return
+	}
+	if user.EmployeeID != empID {
+		for i := range items {
+			items[i].Reserved = items[i].ReservedBy != 0
+			items[i].ReservedByMe = items[i].ReservedBy == user.ID
+		}
+	}
+	render.JSON(w, r, ResponseWishlist{Response: response.OK(), EmployeeID: empID, Items: items})
+}
+
+// linkedUser returns the current user, who must be linked to an employee to keep a wishlist.
+func linkedUser(w http.ResponseWriter, r *http.Request, log *slog.Logger, userRepo jwt.UserFinder) (entities.User, bool) {
+	user, ok := jwt.CurrentUser(w, r, log, userRepo)
+	if !ok {
+		return entities.User{}, false
+	}
+	if user.EmployeeID == 0 {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, response.Error("your account is not linked to an employee"))
+		return entities.User{}, false
+	}
+	return user, true
+}
+
+// visibleWishlist resolves the employee from the URL and checks that the current user is the
+// employee, an admin or one of the employee's subscribers.
+func visibleWishlist(w http.ResponseWriter, r *http.Request, log *slog.Logger, empRepo Employees, subsRepo Subscribers,
+	userRepo jwt.UserFinder) (entities.User, entities.Employee, bool) {
+	user, ok := jwt.CurrentUser(w, r, log, userRepo)
+	if !ok {
+		return entities.User{}, entities.Employee{}, false
+	}
+	empID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, response.Error("Invalid employee ID"))
+		return entities.User{}, entities.Employee{}, false
+	}
+	employee, err := empRepo.FindEmployeeById(r.Context(), empID)
+	if err != nil || employee.ArchivedAt != nil {
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, response.Error("employee not found"))
+		return entities.User{}, entities.Employee{}, false
+	}
+	if user.EmployeeID == employee.ID || jwt.IsAdmin(r.Context()) {
+		return user, employee, true
+	}
+	subscribed, err := subsRepo.IsSubscribed(r.Context(), user.ID, employee.ID)
+	if err != nil {
+		log.Error("Failed to check subscription", errMsg.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, response.Error("Failed to get wishlist"))
+		return entities.User{}, entities.Employee{}, false
+	}
+	if subscribed {
+		return user, employee, true
+	}
+	render.Status(r, http.StatusForbidden)
+	render.JSON(w, r, response.Error("only subscribers of the employee can see the wishlist"))
+	return entities.User{}, entities.Employee{}, false
+}
+
+func decodeItem(w http.ResponseWriter, r *http.Request, log *slog.Logger, req *RequestItem) bool {
+	if err := render.DecodeJSON(r.Body, req); err != nil {
+		log.Error("failed to decode request body", errMsg.Err(err))
+		render.JSON(w, r, response.Error("failed to decode request"))
+		return false
+	}
+	if err := validator.New().Struct(req); err != nil {
+		validateErr := err.(validator.ValidationErrors)
+		log.Error("Invalid request", errMsg.Err(err))
+		render.JSON(w, r, response.ValidationError(validateErr))
+		return false
+	}
+	if req.PriceMin != nil && req.PriceMax != nil && *req.PriceMin > *req.PriceMax {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, response.Error("price_min must not exceed price_max"))
+		return false
+	}
+	return true
+}
+
+func itemID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(chi.URLParam(r, "itemId"))
+	if err != nil {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, response.Error("Invalid item ID"))
+		return 0, false
+	}
+	return id, true
+}
+
+func containsItem(items []entities.WishlistItem, id int) bool {
+	for _, item := range items {
+		if item.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,249 @@
+package importer
+
+import (
+	"birthday-service/internal/entities"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+
+	maxFieldLength = 100
+)
+
+var DefaultDateFormats = []string{"02.01.2006", "2006-01-02", "02/01/2006", "2.1.2006", "02-01-2006"}
+
+type Mapping struct {
+	Name       string
+	Birthday   string
+	ExternalID string
+	Email      string
+}
+
+func DefaultMapping() Mapping {
+	return Mapping{Name: "name", Birthday: "birthday", ExternalID: "external_id", Email: "email"}
+}
+
+type RowError struct {
+	Row    int    `json:"row"`
+	Column string `json:"column,omitempty"`
+	Error  string `json:"error"`
+}
+
+type Result struct {
+	Employees []entities.Employee
+	Errors    []RowError
+}
+
+func FormatFromFilename(filename string) string {
+	switch {
+	case strings.HasSuffix(strings.ToLower(filename), ".xlsx"):
+		return FormatXLSX
+	case strings.HasSuffix(strings.ToLower(filename), ".csv"):
+		return FormatCSV
+	}
+	return ""
+}
+
+func Read(r io.Reader, format string, mapping Mapping, dateFormats []string) (Result, error) {
+	if len(dateFormats) == 0 {
+		dateFormats = DefaultDateFormats
+	}
+	var (
+		records [][]string
+		err     error
+	)
+	switch format {
+	case FormatCSV, "":
+		records, err = readCSV(r)
+	case FormatXLSX:
+		records, err = readXLSX(r)
+	default:
+		return Result{}, fmt.Errorf("unsupported format %q", format)
+	}
+	if err != nil {
+		return Result{}, err
+	}
+	return parseRecords(records, mapping, dateFormats, format == FormatXLSX)
+}
+
+// utf8BOM is prepended to CSV files by Excel and would otherwise end up in the first header.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+func readCSV(r io.Reader) ([][]string, error) {
+	buffered := bufio.NewReader(r)
+	if prefix, _ := buffered.Peek(len(utf8BOM)); bytes.Equal(prefix, utf8BOM) {
+		_, _ = buffered.Discard(len(utf8BOM))
+	}
+	// The delimiter is sniffed from the header line alone, however long it is.
+	header, err := buffered.ReadBytes('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	reader := csv.NewReader(io.MultiReader(bytes.NewReader(header), buffered))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	return reader.ReadAll()
+}
+
+func readXLSX(r io.Reader) ([][]string, error) {
+	f, err := excelize.OpenReader(r, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open xlsx: %w", err)
+	}
+	defer f.Close()
+	sheet := f.GetSheetName(0)
+	if sheet == "" {
+		return nil, errors.New("xlsx file has no sheets")
+	}
+	return f.GetRows(sheet)
+}
+
+func parseRecords(records [][]string, mapping Mapping, dateFormats []string, serialDates bool) (Result, error) {
+	if len(records) == 0 {
+		return Result{}, errors.New("file is empty")
+	}
+
+	header := make(map[string]int, len(records[0]))
+	for i, column := range records[0] {
+		header[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	nameIdx, ok := header[strings.ToLower(mapping.Name)]
+	if !ok {
+		return Result{}, fmt.Errorf("column %q not found", mapping.Name)
+	}
+	birthdayIdx, ok := header[strings.ToLower(mapping.Birthday)]
+	if !ok {
+		return Result{}, fmt.Errorf("column %q not found", mapping.Birthday)
+	}
+	externalIdx, hasExternal := header[strings.ToLower(mapping.ExternalID)]
+	emailIdx, hasEmail := header[strings.ToLower(mapping.Email)]
+
+	var result Result
+	seen := make(map[string]int)
+	for i, record := range records[1:] {
+		row := i + 2
+		if isBlank(record) {
+			continue
+		}
+
+		name := cell(record, nameIdx)
+		birthdayStr := cell(record, birthdayIdx)
+		externalID := ""
+		if hasExternal {
+			externalID = cell(record, externalIdx)
+		}
+		email := ""
+		if hasEmail {
+			email = cell(record, emailIdx)
+		}
+
+		valid := true
+		if name == "" {
+			result.Errors = append(result.Errors, RowError{Row: row, Column: mapping.Name, Error: "name is required"})
+			valid = false
+		} else if len(name) > maxFieldLength {
+			result.Errors = append(result.Errors, RowError{Row: row, Column: mapping.Name, Error: "name is too long"})
+			valid = false
+		}
+		if len(externalID) > maxFieldLength {
+			result.Errors = append(result.Errors, RowError{Row: row, Column: mapping.ExternalID, Error: "external id is too long"})
+			valid = false
+		} else if externalID != "" {
+			if first, dup := seen[externalID]; dup {
+				result.Errors = append(result.Errors, RowError{Row: row, Column: mapping.ExternalID,
+					Error: fmt.Sprintf("external id duplicates row %d", first)})
+				valid = false
+			} else {
+				seen[externalID] = row
+			}
+		}
+		if email != "" && (len(email) > maxFieldLength || !strings.Contains(email, "@")) {
+			result.Errors = append(result.Errors, RowError{Row: row, Column: mapping.Email, Error: "invalid email"})
+			valid = false
+		}
+		birthday, err := parseDate(birthdayStr, dateFormats, serialDates)
+		if err != nil {
+			result.Errors = append(result.Errors, RowError{Row: row, Column: mapping.Birthday, Error: err.Error()})
+			valid = false
+		}
+
+		if valid {
+			result.Employees = append(result.Employees, entities.Employee{Name: name, Birthday: birthday,
+				ExternalID: externalID, Email: email})
+		}
+	}
+	return result, nil
+}
+
+func ParseDate(value string, dateFormats []string) (time.Time, error) {
+	return parseDate(value, dateFormats, false)
+}
+
+func parseDate(value string, dateFormats []string, serialDates bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("birthday is required")
+	}
+	var (
+		birthday time.Time
+		ok       bool
+	)
+	if serialDates {
+		birthday, ok = serialDate(value)
+	}
+	for i := 0; !ok && i < len(dateFormats); i++ {
+		if t, err := time.Parse(dateFormats[i], value); err == nil {
+			birthday, ok = t, true
+		}
+	}
+	if !ok {
+		return time.Time{}, fmt.Errorf("unrecognised date %q", value)
+	}
+	if birthday.After(time.Now()) {
+		return time.Time{}, fmt.Errorf("birthday %q is in the future", value)
+	}
+	return birthday, nil
+}
+
+// serialDate converts the day number Excel stores for date cells.
+func serialDate(value string) (time.Time, bool) {
+	serial, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	t, err := excelize.ExcelDateToTime(serial, false)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), true
+}
+
+func cell(record []string, idx int) string {
+	if idx >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[idx])
+}
+
+func isBlank(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
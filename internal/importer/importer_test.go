@@ -0,0 +1,146 @@
+package importer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "comma", input: "name,birthday\nAnna,14.03.1990\n"},
+		{name: "semicolon", input: "name;birthday\nAnna;14.03.1990\n"},
+		{name: "byte order mark", input: "\xEF\xBB\xBFname;birthday\nAnna;14.03.1990\n"},
+		{name: "comma in a semicolon file", input: "name;birthday\n\"Smith, Anna\";14.03.1990\n"},
+		{name: "no trailing newline", input: "name,birthday\nAnna,14.03.1990"},
+		{name: "long header", input: "name," + strings.Repeat("x", 5000) + ",birthday\nAnna,,14.03.1990\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Read(strings.NewReader(tt.input), FormatCSV, DefaultMapping(), nil)
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			if len(result.Errors) != 0 || len(result.Employees) != 1 {
+				t.Fatalf("Read = %+v", result)
+			}
+			employee := result.Employees[0]
+			if !strings.Contains(employee.Name, "Anna") || !employee.Birthday.Equal(time.Date(1990, time.March, 14, 0, 0, 0, 0, time.UTC)) {
+				t.Fatalf("employee = %+v", employee)
+			}
+		})
+	}
+}
+
+func TestReadRowErrors(t *testing.T) {
+	input := "name,birthday,external_id,email\n" +
+		"Anna,14.03.1990,A1,anna@example.com\n" +
+		",14.03.1990,,\n" +
+		"Boris,31.02.1990,,\n" +
+		"Vera,14.03.1990,A1,\n" +
+		"Gleb,14.03.1990,,not-an-email\n" +
+		",,,\n"
+	result, err := Read(strings.NewReader(input), FormatCSV, DefaultMapping(), nil)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(result.Employees) != 1 {
+		t.Fatalf("imported %d employees, want 1", len(result.Employees))
+	}
+	wantRows := []int{3, 4, 5, 6}
+	if len(result.Errors) != len(wantRows) {
+		t.Fatalf("errors = %+v", result.Errors)
+	}
+	for i, row := range wantRows {
+		if result.Errors[i].Row != row {
+			t.Fatalf("error %d is for row %d, want %d", i, result.Errors[i].Row, row)
+		}
+	}
+}
+
+func TestReadMissingColumn(t *testing.T) {
+	if _, err := Read(strings.NewReader("name,date\nAnna,14.03.1990\n"), FormatCSV, DefaultMapping(), nil); err == nil {
+		t.Fatal("expected an error for a missing birthday column")
+	}
+}
+
+func TestReadXLSX(t *testing.T) {
+	f := excelize.NewFile()
+	sheet := f.GetSheetName(0)
+	_ = f.SetSheetRow(sheet, "A1", &[]any{"name", "birthday"})
+	_ = f.SetSheetRow(sheet, "A2", &[]any{"Anna", time.Date(1990, time.March, 14, 0, 0, 0, 0, time.UTC)})
+	_ = f.SetSheetRow(sheet, "A3", &[]any{"Boris", "15.04.1985"})
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	result, err := Read(&buf, FormatXLSX, DefaultMapping(), nil)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(result.Errors) != 0 || len(result.Employees) != 2 {
+		t.Fatalf("Read = %+v", result)
+	}
+	want := []time.Time{
+		time.Date(1990, time.March, 14, 0, 0, 0, 0, time.UTC),
+		time.Date(1985, time.April, 15, 0, 0, 0, 0, time.UTC),
+	}
+	for i, employee := range result.Employees {
+		if !employee.Birthday.Equal(want[i]) {
+			t.Fatalf("%s: birthday = %v, want %v", employee.Name, employee.Birthday, want[i])
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	future := time.Now().AddDate(1, 0, 0)
+	tests := []struct {
+		name        string
+		value       string
+		serialDates bool
+		want        time.Time
+		wantErr     bool
+	}{
+		{name: "dotted", value: "14.03.1990", want: time.Date(1990, time.March, 14, 0, 0, 0, 0, time.UTC)},
+		{name: "iso", value: "1990-03-14", want: time.Date(1990, time.March, 14, 0, 0, 0, 0, time.UTC)},
+		{name: "slashes", value: "14/03/1990", want: time.Date(1990, time.March, 14, 0, 0, 0, 0, time.UTC)},
+		{name: "no leading zeros", value: "4.3.1990", want: time.Date(1990, time.March, 4, 0, 0, 0, 0, time.UTC)},
+		{name: "excel serial", value: "32946", serialDates: true, want: time.Date(1990, time.March, 14, 0, 0, 0, 0, time.UTC)},
+		{name: "serial outside xlsx", value: "32946", wantErr: true},
+		{name: "empty", value: "", wantErr: true},
+		{name: "impossible date", value: "31.02.1990", wantErr: true},
+		{name: "future date", value: future.Format("02.01.2006"), wantErr: true},
+		{name: "future serial", value: "80000", serialDates: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseDate(tt.value, DefaultDateFormats, tt.serialDates)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: parseDate(%q) error = %v, want error %v", tt.name, tt.value, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !got.Equal(tt.want) {
+			t.Errorf("%s: parseDate(%q) = %v, want %v", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormatFromFilename(t *testing.T) {
+	tests := map[string]string{
+		"staff.csv":  FormatCSV,
+		"Staff.XLSX": FormatXLSX,
+		"staff.xls":  "",
+		"staff":      "",
+	}
+	for filename, want := range tests {
+		if got := FormatFromFilename(filename); got != want {
+			t.Errorf("FormatFromFilename(%q) = %q, want %q", filename, got, want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package leader
+
+import (
+	errMsg "birthday-service/internal/err"
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Elector makes sure a job runs on a single instance at a time. Leadership is a Postgres
+// session-level advisory lock held on a dedicated connection, so it is released by the
+// server as soon as the leader exits or its connection dies, and another instance takes over.
+type Elector struct {
+	db       *pgxpool.Pool
+	key      int64
+	interval time.Duration
+	log      *slog.Logger
+}
+
+func NewElector(db *pgxpool.Pool, key int64, interval time.Duration, log *slog.Logger) *Elector {
+	return &Elector{db: db, key: key, interval: interval, log: log.With(slog.Int64("lock_key", key))}
+}
+
+// Run campaigns for leadership until ctx is cancelled and calls fn while this instance is the
+// leader. The context passed to fn is cancelled when leadership is lost.
+func (e *Elector) Run(ctx context.Context, fn func(ctx context.Context)) {
+	for {
+		e.lead(ctx, fn)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(e.interval):
+		}
+	}
+}
+
+func (e *Elector) lead(ctx context.Context, fn func(ctx context.Context)) {
+	conn, err := e.db.Acquire(ctx)
+	if err != nil {
+		e.log.Error("failed to acquire connection for leader election", errMsg.Err(err))
+		return
+	}
+	defer conn.Release()
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, e.key).Scan(&acquired); err != nil {
+		e.log.Error("failed to try leader lock", errMsg.Err(err))
+		return
+	}
+	if !acquired {
+		return
+	}
+	e.log.Info("became leader")
+
+	leaderCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(leaderCtx)
+	}()
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+watch:
+	for {
+		select {
+		case <-ctx.Done():
+			break watch
+		case <-done:
+			break watch
+		case <-ticker.C:
+			if err := conn.Ping(ctx); err != nil {
+				e.log.Error("lost leader connection", errMsg.Err(err))
+				break watch
+			}
+		}
+	}
+	cancel()
+	<-done
+
+	unlockCtx, cancelUnlock := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelUnlock()
+	if _, err := conn.Exec(unlockCtx, `SELECT pg_advisory_unlock($1)`, e.key); err != nil {
+		// Closing the session is the only safe way to make sure the lock does not
+		// stay behind on a pooled connection.
+		e.log.Error("failed to release leader lock", errMsg.Err(err))
+		conn.Conn().Close(unlockCtx)
+	}
+	e.log.Info("leadership released")
+}
@@ -0,0 +1,54 @@
+package milestone
+
+import (
+	"birthday-service/internal/config"
+	"time"
+)
+
+// Milestone is what the matching rules ask for a round birthday.
+type Milestone struct {
+	Age        int
+	DaysBefore int
+	Recipients []string
+}
+
+type Rules []config.MilestoneRule
+
+// Match merges every rule matching age: the earliest warning wins and recipients are combined.
+func (r Rules) Match(age int) (Milestone, bool) {
+	milestone := Milestone{Age: age}
+	matched := false
+	seen := make(map[string]bool)
+	for _, rule := range r {
+		if !rule.Matches(age) {
+			continue
+		}
+		matched = true
+		if rule.DaysBefore > milestone.DaysBefore {
+			milestone.DaysBefore = rule.DaysBefore
+		}
+		for _, recipient := range rule.Recipients {
+			if !seen[recipient] {
+				seen[recipient] = true
+				milestone.Recipients = append(milestone.Recipients, recipient)
+			}
+		}
+	}
+	return milestone, matched
+}
+
+// MaxDaysBefore is the earliest any rule warns about a milestone.
+func (r Rules) MaxDaysBefore() int {
+	days := 0
+	for _, rule := range r {
+		if rule.DaysBefore > days {
+			days = rule.DaysBefore
+		}
+	}
+	return days
+}
+
+// Turning returns the age reached on occurrence, a celebration date of birthday.
+func Turning(birthday, occurrence time.Time) int {
+	return occurrence.Year() - birthday.Year()
+}
@@ -0,0 +1,58 @@
+package milestone
+
+import (
+	"birthday-service/internal/config"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRulesMatch(t *testing.T) {
+	rules := Rules{
+		{Every: 10, DaysBefore: 7, Recipients: []string{"hr@example.com"}},
+		{Ages: []int{50, 55}, DaysBefore: 14, Recipients: []string{"ceo@example.com", "hr@example.com"}},
+	}
+	tests := []struct {
+		age     int
+		want    Milestone
+		matched bool
+	}{
+		{age: 30, want: Milestone{Age: 30, DaysBefore: 7, Recipients: []string{"hr@example.com"}}, matched: true},
+		{age: 55, want: Milestone{Age: 55, DaysBefore: 14, Recipients: []string{"ceo@example.com", "hr@example.com"}}, matched: true},
+		{age: 50, want: Milestone{Age: 50, DaysBefore: 14, Recipients: []string{"hr@example.com", "ceo@example.com"}}, matched: true},
+		{age: 31, want: Milestone{Age: 31}},
+		{age: 0, want: Milestone{Age: 0}},
+	}
+	for _, tt := range tests {
+		got, matched := rules.Match(tt.age)
+		if matched != tt.matched || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Match(%d) = %+v, %v, want %+v, %v", tt.age, got, matched, tt.want, tt.matched)
+		}
+	}
+}
+
+func TestMaxDaysBefore(t *testing.T) {
+	tests := []struct {
+		rules Rules
+		want  int
+	}{
+		{rules: nil, want: 0},
+		{rules: Rules{{Every: 10, DaysBefore: 3}, {Ages: []int{18}, DaysBefore: 21}}, want: 21},
+	}
+	for _, tt := range tests {
+		if got := tt.rules.MaxDaysBefore(); got != tt.want {
+			t.Errorf("MaxDaysBefore() = %d, want %d", got, tt.want)
+		}
+	}
+}
+
+func TestTurning(t *testing.T) {
+	birthday := time.Date(1986, time.December, 31, 0, 0, 0, 0, time.UTC)
+	occurrence := time.Date(2026, time.December, 31, 0, 0, 0, 0, time.UTC)
+	if got := Turning(birthday, occurrence); got != 40 {
+		t.Fatalf("Turning = %d, want 40", got)
+	}
+	if (config.MilestoneRule{Every: 10}).Matches(Turning(birthday, occurrence)) != true {
+		t.Fatal("a 40th birthday should match every 10 years")
+	}
+}
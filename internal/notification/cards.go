@@ -0,0 +1,109 @@
+package notification
+
+import (
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/internal/schedule"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type CardSource interface {
+	GetDueCards(ctx context.Context, from, to time.Time) ([]entities.Card, error)
+}
+
+// Cards sends birthday people the messages colleagues left on their greeting card board.
+type Cards struct {
+	source     CardSource
+	deliveries DeliveryLog
+	templates  *Templates
+	log        *slog.Logger
+}
+
+func NewCards(source CardSource, deliveries DeliveryLog, templates *Templates, log *slog.Logger) *Cards {
+	return &Cards{source: source, deliveries: deliveries, templates: templates, log: log}
+}
+
+type CardData struct {
+	Recipient string
+	Name      string
+	Messages  []entities.BoardMessage
+}
+
+// SendCards hands each card to sink once, on the birthday in the recipient's time zone and not
+// before their delivery hour. Users who unsubscribed from everything get no card; the messages
+// stay on the board. It returns how many cards were sent.
+func (c *Cards) SendCards(ctx context.Context, sink Sink, now time.Time) int {
+	log := c.log
+	today := time.Date(now.UTC().Year(), now.UTC().Month(), now.UTC().Day(), 0, 0, 0, 0, time.UTC)
+	from, to := today.AddDate(0, 0, -1), today.AddDate(0, 0, 1)
+	cards, err := c.source.GetDueCards(ctx, from, to)
+	if err != nil {
+		log.Error("failed to get greeting cards", errMsg.Err(err))
+		return 0
+	}
+	delivered, err := c.deliveries.GetDeliveries(ctx, from, to)
+	if err != nil {
+		log.Error("failed to get delivery log", errMsg.Err(err))
+		return 0
+	}
+	seen := make(map[string]bool, len(delivered))
+	for _, delivery := range delivered {
+		seen[deliveryKey(delivery)] = true
+	}
+
+	var (
+		sent  int
+		skips []Skip
+	)
+	for _, card := range cards {
+		recipient := card.Recipient
+		if recipient.Email == "" {
+			continue
+		}
+		if !schedule.LocalDate(recipient, now).Equal(card.Occurrence) || schedule.BeforeDeliveryHour(recipient, now) {
+			continue
+		}
+		delivery := entities.Delivery{Recipient: recipient.Email, EventKey: fmt.Sprintf("card:%d", card.Employee.ID),
+			Occurrence: card.Occurrence}
+		if seen[deliveryKey(delivery)] {
+			continue
+		}
+		if recipient.UnsubscribedAll {
+			skips = append(skips, Skip{EmployeeID: card.Employee.ID, Recipient: recipient.Email,
+				Key:    delivery.EventKey + ":" + card.Occurrence.Format("2006-01-02") + ":unsubscribed",
+				Reason: "the recipient unsubscribed from all notifications"})
+			continue
+		}
+		subject, body, err := c.templates.Render(TemplateCard, CardData{Recipient: recipient.Email,
+			Name: card.Employee.Name, Messages: card.Messages})
+		if err != nil {
+			log.Error("failed to render greeting card", slog.Int("emp_id", card.Employee.ID), errMsg.Err(err))
+			continue
+		}
+		err = sink.Send(ctx, Message{
+			EmployeeID: card.Employee.ID,
+			Key:        delivery.EventKey + ":" + card.Occurrence.Format("2006-01-02"),
+			To:         []string{recipient.Email},
+			Subject:    subject,
+			Body:       body,
+			Deliveries: []entities.Delivery{delivery},
+		})
+		if err != nil {
+			log.Error("failed to send greeting card", slog.Int("emp_id", card.Employee.ID), errMsg.Err(err))
+			continue
+		}
+		sent++
+	}
+	if recorder, ok := sink.(SkipRecorder); ok && len(skips) > 0 {
+		if err := recorder.RecordSkips(ctx, skips); err != nil {
+			log.Error("failed to record skipped greeting cards", errMsg.Err(err))
+		}
+	}
+	if sent > 0 {
+		log.Info("greeting cards sent", slog.Int("cards", sent))
+	}
+	return sent
+}
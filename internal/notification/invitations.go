@@ -0,0 +1,54 @@
+package notification
+
+import (
+	"birthday-service/internal/entities"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Invitations asks colleagues to take part in preparations for a birthday.
+type Invitations struct {
+	templates *Templates
+	sink      Sink
+	baseURL   string
+}
+
+func NewInvitations(templates *Templates, sink Sink, baseURL string) *Invitations {
+	return &Invitations{templates: templates, sink: sink, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+type CollectionData struct {
+	Recipient  string
+	Collection entities.Collection
+	Date       string
+	URL        string
+}
+
+// InviteToCollection sends one invitation per recipient and returns how many were handed to the sink.
+func (i *Invitations) InviteToCollection(ctx context.Context, collection entities.Collection, recipients []entities.User) (int, error) {
+	sent := 0
+	for _, recipient := range recipients {
+		subject, body, err := i.templates.Render(TemplateCollection, CollectionData{
+			Recipient:  recipient.Email,
+			Collection: collection,
+			Date:       collection.Occurrence.Format("02 January"),
+			URL:        fmt.Sprintf("%s/collections/%d", i.baseURL, collection.ID),
+		})
+		if err != nil {
+			return sent, err
+		}
+		err = i.sink.Send(ctx, Message{
+			EmployeeID: collection.EmployeeID,
+			Key:        fmt.Sprintf("collection:%d", collection.ID),
+			To:         []string{recipient.Email},
+			Subject:    subject,
+			Body:       body,
+		})
+		if err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
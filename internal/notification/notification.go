@@ -1,58 +1,601 @@
 package notification
 
 import (
-	"birthday-service/internal/config"
+	"birthday-service/internal/calendar"
+	"birthday-service/internal/entities"
 	errMsg "birthday-service/internal/err"
 	empHandlers "birthday-service/internal/handlers/emp"
-	subHandlers "birthday-service/internal/handlers/subs"
+	"birthday-service/internal/milestone"
+	"birthday-service/internal/privacy"
+	"birthday-service/internal/schedule"
+	"birthday-service/internal/unsubscribe"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log/slog"
-	"net/smtp"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
-func SendEmail(cfg *config.ConfigSMTP, to []string, subject, body string) error {
-	auth := smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPHost)
-	msg := []byte("To: " + strings.Join(to, ",") + "\r\n" +
-		"Subject: " + subject + "\r\n" +
-		"\r\n" +
-		body + "\r\n")
+type RunStats struct {
+	Employees  int           `json:"employees"`
+	Messages   int           `json:"messages"`
+	Recipients int           `json:"recipients"`
+	Skipped    int           `json:"skipped"`
+	Deferred   int           `json:"deferred"`
+	Failed     int           `json:"failed"`
+	Duration   time.Duration `json:"duration_ns"`
+}
+
+type DeliveryLog interface {
+	GetDeliveries(ctx context.Context, from, to time.Time) ([]entities.Delivery, error)
+}
+
+// EventItem describes one upcoming event of an employee as shown to a recipient.
+type EventItem struct {
+	EmployeeID int
+	EventID    int
+	Type       string
+	// Title names the occasion: "birthday", "work anniversary" or the custom event's name.
+	Title string
+	Name  string
+	// Date is formatted according to the employee's privacy settings and empty when hidden.
+	Date       string
+	Occurrence time.Time
+	DaysLeft   int
+	// Years counts recurrences since the event's first date; it is zero for birthdays.
+	Years int
+	// Age is the age reached on a birthday, zero when the birth year is hidden.
+	Age int
+	// Milestone marks a round birthday matched by the milestone rules.
+	Milestone bool
+	// Celebration is set when the reminder moved to the business day before a day off.
+	Celebration string
+	// UnsubscribeURL cancels the subscription this recipient was notified through.
+	UnsubscribeURL string
+	// BoardURL is where colleagues leave messages on a birthday's greeting card.
+	BoardURL string
+	// WishlistURL points to the employee's wishlist when they keep one.
+	WishlistURL string
+}
+
+type BirthdayData struct {
+	Recipient         string
+	Birthday          EventItem
+	UnsubscribeAllURL string
+}
+
+type EventData struct {
+	Recipient         string
+	Event             EventItem
+	UnsubscribeAllURL string
+}
+
+// MilestoneData is sent to the extra recipients of a milestone rule.
+type MilestoneData struct {
+	Recipient string
+	Birthday  EventItem
+}
+
+type DigestData struct {
+	Recipient         string
+	Mode              string
+	Events            []EventItem
+	UnsubscribeAllURL string
+	// Birthdays holds the same items as Events for custom templates written before other event types.
+	Birthdays []EventItem
+}
+
+// upcomingDays is how many days ahead of a recipient's local date events are announced;
+// milestone rules may ask for more.
+const upcomingDays = 7
+
+type Notifier struct {
+	empRepository empHandlers.Employee
+	deliveries    DeliveryLog
+	templates     *Templates
+	links         *unsubscribe.Links
+	calendar      *calendar.Loader
+	milestones    milestone.Rules
+	workers       int
+	log           *slog.Logger
+}
 
-	addr := fmt.Sprintf("%s:%d", cfg.SMTPHost, cfg.SMTPPort)
-	return smtp.SendMail(addr, auth, cfg.SMTPUsername, to, msg)
+func NewNotifier(empRepository empHandlers.Employee, deliveries DeliveryLog, templates *Templates, links *unsubscribe.Links,
+	calendar *calendar.Loader, milestones milestone.Rules, workers int, log *slog.Logger) *Notifier {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Notifier{empRepository: empRepository, deliveries: deliveries, templates: templates, links: links,
+		calendar: calendar, milestones: milestones, workers: workers, log: log}
+}
+
+type recipientPlan struct {
+	user  entities.User
+	today time.Time
+	items []EventItem
+}
+
+// runData is what a run reads from the database before deciding who hears about what.
+type runData struct {
+	today    time.Time
+	workdays *calendar.Calendar
+	upcoming []entities.UpcomingEvent
+	// seen holds the delivery keys of notifications sent earlier.
+	seen map[string]bool
 }
 
-func SendBirthdayNotifications(ctx context.Context, subRepository subHandlers.Sub, empRepository empHandlers.Employee, cfg *config.ConfigSMTP, log *slog.Logger) {
+// SendBirthdayNotifications plans messages for birthdays and other employee events in the week
+// starting at each recipient's local date and hands them to sink. Every recipient hears about an
+// event once: either in its own message or in a daily or weekly digest, depending on the recipient's
+// digest mode. Milestone birthdays are announced as early as their rules ask and also go to the
+// rules' extra recipients. Subscriptions asking for it are reminded as of the business day before
+// a weekend or holiday. Muted and unsubscribed recipients are skipped, and the skips are recorded when
+// the sink keeps a history; recipients in their quiet hours or before their delivery hour are deferred
+// to a later run.
+//
+// The run is split into collect, filter and plan stages followed by sending.
+func (n *Notifier) SendBirthdayNotifications(ctx context.Context, sink Sink, now time.Time) RunStats {
+	started := time.Now()
+	var stats RunStats
+	log := n.log
+
+	data, err := n.collect(ctx, now)
+	if err != nil {
+		log.Error("failed to collect upcoming events", errMsg.Err(err))
+		return stats
+	}
+	plans, skips := n.filter(data, now, &stats)
+	messages := n.plan(plans, &stats)
+
+	if recorder, ok := sink.(SkipRecorder); ok && len(skips) > 0 {
+		if err := recorder.RecordSkips(ctx, skips); err != nil {
+			log.Error("failed to record skipped notifications", errMsg.Err(err))
+		}
+	}
 
-	employees, err := empRepository.GetUpcomingBirthdays(ctx)
+	notices, err := n.planMilestoneNotices(ctx, data.today, data.seen)
 	if err != nil {
-		log.Error("failed to get upcoming birthdays", errMsg.Err(err))
-		return
+		log.Error("failed to plan milestone notices", errMsg.Err(err))
+		stats.Failed++
 	}
-	for _, employee := range employees {
-		users, err := subRepository.GetSubs(ctx, employee.ID)
+	messages = append(messages, notices...)
+
+	n.send(ctx, sink, messages, &stats)
+
+	stats.Duration = time.Since(started)
+	log.Info("notification run finished",
+		slog.Int("employees", stats.Employees),
+		slog.Int("messages", stats.Messages),
+		slog.Int("recipients", stats.Recipients),
+		slog.Int("skipped", stats.Skipped),
+		slog.Int("deferred", stats.Deferred),
+		slog.Int("failed", stats.Failed),
+		slog.Duration("duration", stats.Duration))
+	return stats
+}
+
+// collect loads the calendar, the upcoming events with their subscribers and the delivery log.
+func (n *Notifier) collect(ctx context.Context, now time.Time) (runData, error) {
+	// Local dates differ from the UTC date by at most a day, so one window covers every recipient;
+	// it reaches further ahead for reminders moved before a run of days off.
+	today := time.Date(now.UTC().Year(), now.UTC().Month(), now.UTC().Day(), 0, 0, 0, 0, time.UTC)
+	from, to := today.AddDate(0, 0, -1), today.AddDate(0, 0, n.maxNoticeDays()+1+calendar.MaxShift)
+	data := runData{today: today}
+
+	workdays, err := n.calendar.Load(ctx, from, to)
+	if err != nil {
+		return data, fmt.Errorf("failed to load calendar: %w", err)
+	}
+	data.workdays = workdays
+	data.upcoming, err = n.empRepository.GetUpcomingWithSubscribers(ctx, from, to, now)
+	if err != nil {
+		return data, fmt.Errorf("failed to get upcoming birthdays: %w", err)
+	}
+	delivered, err := n.deliveries.GetDeliveries(ctx, from, to)
+	if err != nil {
+		return data, fmt.Errorf("failed to get delivery log: %w", err)
+	}
+	data.seen = make(map[string]bool, len(delivered))
+	for _, delivery := range delivered {
+		data.seen[deliveryKey(delivery)] = true
+	}
+	return data, nil
+}
+
+// filter decides which subscriber hears about which event at now and groups the items per recipient.
+// Items outside the recipient's notice window, already delivered or deferred are left out; skipped
+// ones are returned so they can be recorded.
+func (n *Notifier) filter(data runData, now time.Time, stats *RunStats) ([]*recipientPlan, []Skip) {
+	var (
+		plans  []*recipientPlan
+		byUser = make(map[int]*recipientPlan)
+		skips  []Skip
+	)
+	for _, event := range data.upcoming {
+		counted := false
+		for _, subscriber := range event.Subscribers {
+			localToday := schedule.LocalDate(subscriber.User, now)
+			item := eventItem(event, localToday, n.milestones)
+			if subscriber.ShiftToBusinessDay {
+				item = shiftToBusinessDay(item, data.workdays, localToday)
+			}
+			if item.DaysLeft < 0 || item.DaysLeft > n.noticeDays(subscriber.User, localToday, item) {
+				continue
+			}
+			if !counted {
+				stats.Employees++
+				counted = true
+			}
+			if data.seen[deliveryKey(itemDelivery(subscriber.Email, item))] {
+				stats.Skipped++
+				continue
+			}
+			if code, reason := skipReason(event, subscriber, now); code != "" {
+				stats.Skipped++
+				skips = append(skips, Skip{EmployeeID: item.EmployeeID, Recipient: subscriber.Email,
+					Key: eventKey(item) + ":" + item.Occurrence.Format("2006-01-02") + ":" + code, Reason: reason})
+				continue
+			}
+			if schedule.InQuietHours(subscriber.User, now) || schedule.BeforeDeliveryHour(subscriber.User, now) {
+				stats.Deferred++
+				continue
+			}
+			plan, ok := byUser[subscriber.ID]
+			if !ok {
+				plan = &recipientPlan{user: subscriber.User, today: localToday}
+				byUser[subscriber.ID] = plan
+				plans = append(plans, plan)
+			}
+			item.UnsubscribeURL = n.links.URL(subscriber.ID, subscriber.SubscriptionID)
+			if item.Type == entities.EventBirthday && item.Date != "" {
+				item.BoardURL = fmt.Sprintf("%s/birthdays/%d/%d/messages", n.links.BaseURL(), item.EmployeeID,
+					item.Occurrence.Year())
+			}
+			if item.Type == entities.EventBirthday && event.HasWishlist {
+				item.WishlistURL = fmt.Sprintf("%s/emp/%d/wishlist", n.links.BaseURL(), item.EmployeeID)
+			}
+			plan.items = append(plan.items, item)
+		}
+	}
+	return plans, skips
+}
+
+// plan renders the messages for every recipient; a recipient whose message fails to render is
+// counted as failed and left out.
+func (n *Notifier) plan(plans []*recipientPlan, stats *RunStats) []Message {
+	var messages []Message
+	for _, plan := range plans {
+		planned, err := n.planMessages(plan)
 		if err != nil {
-			log.Error("failed to get subscribers", errMsg.Err(err))
+			n.log.Error("failed to render message", slog.Int("user_id", plan.user.ID), errMsg.Err(err))
+			stats.Failed++
 			continue
 		}
-		if len(users) == 0 {
-			continue
+		messages = append(messages, planned...)
+	}
+	return messages
+}
+
+// send hands the messages to sink from a bounded pool of workers.
+func (n *Notifier) send(ctx context.Context, sink Sink, messages []Message, stats *RunStats) {
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		jobs = make(chan Message)
+	)
+	for i := 0; i < n.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for message := range jobs {
+				err := sink.Send(ctx, message)
+				mu.Lock()
+				if err != nil {
+					stats.Failed++
+				} else {
+					stats.Messages++
+					stats.Recipients += len(message.To)
+				}
+				mu.Unlock()
+				if err != nil {
+					n.log.Error("failed to send email", slog.String("key", message.Key), errMsg.Err(err))
+				}
+			}
+		}()
+	}
+	for _, message := range messages {
+		jobs <- message
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+func (n *Notifier) planMessages(plan *recipientPlan) ([]Message, error) {
+	recipient, today := plan.user.Email, plan.today
+	unsubscribeAll := n.links.URL(plan.user.ID, unsubscribe.AllSubscriptions)
+	switch plan.user.DigestMode {
+	case entities.DigestDaily, entities.DigestWeekly:
+		subject, body, err := n.templates.Render(TemplateDigest, DigestData{Recipient: recipient,
+			Mode: plan.user.DigestMode, Events: plan.items, Birthdays: plan.items, UnsubscribeAllURL: unsubscribeAll})
+		if err != nil {
+			return nil, err
+		}
+		message := Message{
+			Key:     digestKey(plan.user.DigestMode, today, plan.items),
+			To:      []string{recipient},
+			Subject: subject,
+			Body:    body,
+			Headers: unsubscribeHeaders(unsubscribeAll),
+		}
+		for _, item := range plan.items {
+			message.Deliveries = append(message.Deliveries, itemDelivery(recipient, item))
 		}
+		return []Message{message}, nil
+	}
 
-		var emails []string
-		for _, user := range users {
-			emails = append(emails, user.Email)
+	messages := make([]Message, 0, len(plan.items))
+	for _, item := range plan.items {
+		subject, body, err := n.renderItem(recipient, item, unsubscribeAll)
+		if err != nil {
+			return nil, err
 		}
+		messages = append(messages, Message{
+			EmployeeID: item.EmployeeID,
+			Key:        eventKey(item) + ":" + item.Occurrence.Format("2006-01-02"),
+			To:         []string{recipient},
+			Subject:    subject,
+			Body:       body,
+			Headers:    unsubscribeHeaders(item.UnsubscribeURL),
+			Deliveries: []entities.Delivery{itemDelivery(recipient, item)},
+		})
+	}
+	return messages, nil
+}
 
-		subject := fmt.Sprintf("It's %s's birthday soon!", employee.Name)
-		body := fmt.Sprintf("Don't forget to congratulate %s on %s!", employee.Name, employee.Birthday.Format("02 January"))
+// planMilestoneNotices tells the extra recipients of milestone rules about round birthdays,
+// whether or not anyone is subscribed to the employee. Only employees showing their full birth date
+// are announced, the same ones UpcomingBirthdaysHandler marks as milestones; those who opted out
+// are left out.
+func (n *Notifier) planMilestoneNotices(ctx context.Context, today time.Time, seen map[string]bool) ([]Message, error) {
+	days := n.milestones.MaxDaysBefore()
+	if days == 0 {
+		return nil, nil
+	}
+	birthdays, err := n.empRepository.GetUpcomingBirthdays(ctx, today, today.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
 
-		err = SendEmail(cfg, emails, subject, body)
-		if err != nil {
-			log.Error("failed to send email", errMsg.Err(err))
-		} else {
-			fmt.Println("email sent")
+	var messages []Message
+	for _, birthday := range birthdays {
+		if birthday.OptOut || privacy.Effective(birthday.Employee, false) != privacy.VisibilityFull {
+			continue
+		}
+		matched, ok := n.milestones.Match(birthday.Age)
+		if !ok {
+			continue
+		}
+		item := EventItem{
+			EmployeeID: birthday.ID,
+			Type:       entities.EventBirthday,
+			Title:      "birthday",
+			Name:       birthday.Name,
+			Date:       birthday.Date.Format("02 January"),
+			Occurrence: birthday.Date,
+			DaysLeft:   int(birthday.Date.Sub(today).Hours() / 24),
+			Age:        birthday.Age,
+			Milestone:  true,
+		}
+		if item.DaysLeft > matched.DaysBefore {
+			continue
+		}
+		for _, recipient := range matched.Recipients {
+			delivery := entities.Delivery{Recipient: recipient, EventKey: fmt.Sprintf("milestone:%d", birthday.ID),
+				Occurrence: birthday.Date}
+			if seen[deliveryKey(delivery)] {
+				continue
+			}
+			subject, body, err := n.templates.Render(TemplateMilestone, MilestoneData{Recipient: recipient, Birthday: item})
+			if err != nil {
+				return messages, err
+			}
+			messages = append(messages, Message{
+				EmployeeID: birthday.ID,
+				Key:        delivery.EventKey + ":" + birthday.Date.Format("2006-01-02"),
+				To:         []string{recipient},
+				Subject:    subject,
+				Body:       body,
+				Deliveries: []entities.Delivery{delivery},
+			})
+		}
+	}
+	return messages, nil
+}
+
+// skipReason explains why the subscriber gets no notification about the event at now; code is empty
+// when they do.
+func skipReason(event entities.UpcomingEvent, subscriber entities.Subscriber, now time.Time) (code, reason string) {
+	switch {
+	case event.OptOut:
+		return "employee_opt_out", "the employee opted out of notifications"
+	case !subscriber.EventCovered:
+		return "event_type_not_covered", "the subscription does not cover " + event.Event.Type + " events"
+	case subscriber.UnsubscribedAll:
+		return "unsubscribed", "the recipient unsubscribed from all notifications"
+	case subscriber.MutedUntil != nil && subscriber.MutedUntil.After(now):
+		return "muted", "notifications are muted until " + subscriber.MutedUntil.Format(time.RFC3339)
+	case subscriber.SubscriptionMuted:
+		return "subscription_muted", "the subscription is muted"
+	}
+	return "", ""
+}
+
+// noticeDays is how many days ahead the item is announced to user. A weekly digest reaches until
+// the day before the next Monday's digest, so every event falls into exactly one of them; events
+// added or subscribed to after Monday go out in a catch-up digest on the following run.
+func (n *Notifier) noticeDays(user entities.User, today time.Time, item EventItem) int {
+	days := upcomingDays
+	if user.DigestMode == entities.DigestWeekly {
+		days = daysUntilMonday(today) - 1
+	}
+	if !item.Milestone {
+		return days
+	}
+	matched, _ := n.milestones.Match(item.Age)
+	return max(days, matched.DaysBefore)
+}
+
+// daysUntilMonday counts the days from today to the next Monday, 7 when today is a Monday.
+func daysUntilMonday(today time.Time) int {
+	days := (int(time.Monday) - int(today.Weekday()) + 7) % 7
+	if days == 0 {
+		return 7
+	}
+	return days
+}
+
+func (n *Notifier) maxNoticeDays() int {
+	return max(upcomingDays, n.milestones.MaxDaysBefore())
+}
+
+// renderItem uses the birthday template for birthdays, a template named after the event type when
+// one is configured, and the generic event template otherwise.
+func (n *Notifier) renderItem(recipient string, item EventItem, unsubscribeAll string) (string, string, error) {
+	if item.Type == entities.EventBirthday {
+		return n.templates.Render(TemplateBirthday,
+			BirthdayData{Recipient: recipient, Birthday: item, UnsubscribeAllURL: unsubscribeAll})
+	}
+	name := TemplateEvent
+	if n.templates.Has(item.Type) {
+		name = item.Type
+	}
+	return n.templates.Render(name, EventData{Recipient: recipient, Event: item, UnsubscribeAllURL: unsubscribeAll})
+}
+
+// unsubscribeHeaders advertises one-click unsubscription as described in RFC 8058.
+func unsubscribeHeaders(link string) map[string]string {
+	return map[string]string{
+		"List-Unsubscribe":      "<" + link + ">",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	}
+}
+
+func eventItem(upcoming entities.UpcomingEvent, today time.Time, milestones milestone.Rules) EventItem {
+	event := upcoming.Event
+	item := EventItem{
+		EmployeeID: upcoming.ID,
+		EventID:    event.ID,
+		Type:       event.Type,
+		Title:      eventTitle(event),
+		Name:       upcoming.Name,
+	}
+	if event.Recurrence == entities.RecurrenceOnce {
+		item.Occurrence = time.Date(event.Date.Year(), event.Date.Month(), event.Date.Day(), 0, 0, 0, 0, time.UTC)
+	} else {
+		item.Occurrence = NextBirthday(event.Date, today)
+	}
+	item.DaysLeft = int(item.Occurrence.Sub(today).Hours() / 24)
+	if event.Type == entities.EventBirthday {
+		item.Date = privacy.FormatBirthday(upcoming.Employee, false, "02 January", "02 January")
+		if privacy.Effective(upcoming.Employee, false) == privacy.VisibilityFull {
+			item.Age = milestone.Turning(upcoming.Birthday, item.Occurrence)
+			_, item.Milestone = milestones.Match(item.Age)
 		}
+	} else {
+		item.Date = item.Occurrence.Format("02 January")
+		if event.Recurrence != entities.RecurrenceOnce {
+			item.Years = item.Occurrence.Year() - event.Date.Year()
+		}
+	}
+	return item
+}
+
+func eventTitle(event entities.Event) string {
+	switch event.Type {
+	case entities.EventBirthday:
+		return "birthday"
+	case entities.EventWorkAnniversary:
+		return "work anniversary"
+	}
+	return event.Name
+}
+
+// shiftToBusinessDay moves the reminder to the business day before a weekend or holiday unless
+// that day has already passed.
+func shiftToBusinessDay(item EventItem, workdays *calendar.Calendar, today time.Time) EventItem {
+	celebration := workdays.PreviousBusinessDay(item.Occurrence)
+	if celebration.Equal(item.Occurrence) || celebration.Before(today) {
+		return item
+	}
+	item.DaysLeft = int(celebration.Sub(today).Hours() / 24)
+	if item.Date != "" {
+		item.Celebration = celebration.Format("Monday, 02 January")
+	}
+	return item
+}
+
+func itemDelivery(recipient string, item EventItem) entities.Delivery {
+	return entities.Delivery{
+		Recipient:  recipient,
+		EventKey:   eventKey(item),
+		Occurrence: item.Occurrence,
+	}
+}
+
+// eventKey identifies an event across runs; birthdays keep the employee-based key used before
+// other event types existed.
+func eventKey(item EventItem) string {
+	if item.Type == entities.EventBirthday {
+		return fmt.Sprintf("birthday:%d", item.EmployeeID)
 	}
+	return fmt.Sprintf("event:%d", item.EventID)
+}
+
+// digestKey identifies a digest by its items, so a digest sent later the same day with other items,
+// e.g. ones deferred by quiet hours or added since, is not dropped as a duplicate of the earlier one.
+func digestKey(mode string, today time.Time, items []EventItem) string {
+	keys := make([]string, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, eventKey(item)+":"+item.Occurrence.Format("2006-01-02"))
+	}
+	sort.Strings(keys)
+	sum := sha256.Sum256([]byte(strings.Join(keys, ",")))
+	return fmt.Sprintf("digest:%s:%s:%s", mode, today.Format("2006-01-02"), hex.EncodeToString(sum[:8]))
+}
+
+func deliveryKey(delivery entities.Delivery) string {
+	return delivery.Recipient + "|" + delivery.EventKey + "|" + delivery.Occurrence.Format("2006-01-02")
+}
+
+// OnDate moves now to the given date keeping the time of day, so previews for another
+// day still honour quiet hours the way a real run at this moment would.
+func OnDate(date, now time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), now.Hour(), now.Minute(), now.Second(), 0, now.Location())
+}
+
+// NextBirthday returns the first birthday on or after today, moving Feb 29 to Feb 28 in non-leap years
+// the same way GetUpcomingBirthdays does.
+func NextBirthday(birthday, today time.Time) time.Time {
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	next := birthdayIn(birthday, today.Year())
+	if next.Before(today) {
+		next = birthdayIn(birthday, today.Year()+1)
+	}
+	return next
+}
+
+func birthdayIn(birthday time.Time, year int) time.Time {
+	day := birthday.Day()
+	if birthday.Month() == time.February && day == 29 && !isLeap(year) {
+		day = 28
+	}
+	return time.Date(year, birthday.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
 }
@@ -0,0 +1,76 @@
+package notification
+
+import (
+	"birthday-service/internal/entities"
+	"context"
+	"errors"
+	"net/textproto"
+	"sync"
+)
+
+type Message struct {
+	EmployeeID int               `json:"employee_id"`
+	Key        string            `json:"key,omitempty"`
+	To         []string          `json:"to"`
+	Subject    string            `json:"subject"`
+	Body       string            `json:"body"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	// Deliveries are logged once the message is accepted, so the same birthday is not announced twice.
+	Deliveries []entities.Delivery `json:"-"`
+}
+
+// Sink delivers planned messages; SendBirthdayNotifications does not care whether they go out or are collected.
+type Sink interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// Transport is a sink that also reports the provider's response, e.g. the mail server's reply.
+type Transport interface {
+	Deliver(ctx context.Context, message Message) (string, error)
+}
+
+// Skip is a notification a run decided not to send.
+type Skip struct {
+	EmployeeID int
+	Recipient  string
+	// Key identifies the skipped notification and reason, so repeated runs can record it once.
+	Key    string
+	Reason string
+}
+
+// SkipRecorder is implemented by sinks that keep a history of skipped notifications.
+type SkipRecorder interface {
+	RecordSkips(ctx context.Context, skips []Skip) error
+}
+
+// IsBounce reports whether the mail server rejected the message permanently (mailbox unavailable,
+// storage exceeded, transaction failed), so retrying would not help.
+func IsBounce(err error) bool {
+	var reply *textproto.Error
+	return errors.As(err, &reply) && reply.Code >= 550 && reply.Code <= 554
+}
+
+// DryRunSink records messages instead of sending them.
+type DryRunSink struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewDryRunSink() *DryRunSink {
+	return &DryRunSink{}
+}
+
+func (s *DryRunSink) Send(ctx context.Context, message Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+func (s *DryRunSink) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	messages := make([]Message, len(s.messages))
+	copy(messages, s.messages)
+	return messages
+}
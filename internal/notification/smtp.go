@@ -0,0 +1,120 @@
+package notification
+
+import (
+	"birthday-service/internal/config"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/smtp"
+	"net/textproto"
+	"sort"
+	"strings"
+)
+
+// SMTPSink is the only way messages reach the mail server.
+type SMTPSink struct {
+	cfg *config.ConfigSMTP
+}
+
+func NewSMTPSink(cfg *config.ConfigSMTP) *SMTPSink {
+	return &SMTPSink{cfg: cfg}
+}
+
+func (s *SMTPSink) Send(ctx context.Context, message Message) error {
+	_, err := s.Deliver(ctx, message)
+	return err
+}
+
+// Deliver sends the message and returns the server's reply; when the server refuses the message,
+// the refusal is returned as the response along with the error.
+func (s *SMTPSink) Deliver(ctx context.Context, message Message) (string, error) {
+	response, err := sendEmail(s.cfg, message.To, formatMessage(message))
+	var reply *textproto.Error
+	if errors.As(err, &reply) {
+		response = fmt.Sprintf("%d %s", reply.Code, reply.Msg)
+	}
+	return response, err
+}
+
+func formatMessage(message Message) []byte {
+	var extra strings.Builder
+	names := make([]string, 0, len(message.Headers))
+	for name := range message.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		extra.WriteString(name + ": " + message.Headers[name] + "\r\n")
+	}
+	return []byte("To: " + strings.Join(message.To, ",") + "\r\n" +
+		"Subject: " + message.Subject + "\r\n" +
+		extra.String() +
+		"\r\n" +
+		message.Body + "\r\n")
+}
+
+// sendEmail does what smtp.SendMail does but also returns the server's reply to the message,
+// which usually carries the provider's queue ID. Credentials are only sent after STARTTLS: a server
+// without it is refused instead of receiving the password in clear text.
+func sendEmail(cfg *config.ConfigSMTP, to []string, msg []byte) (string, error) {
+	client, err := smtp.Dial(fmt.Sprintf("%s:%d", cfg.SMTPHost, cfg.SMTPPort))
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+	if ok, _ := client.Extension("STARTTLS"); !ok {
+		return "", errors.New("smtp: server doesn't support STARTTLS")
+	}
+	if err := client.StartTLS(&tls.Config{ServerName: cfg.SMTPHost}); err != nil {
+		return "", err
+	}
+	if ok, _ := client.Extension("AUTH"); !ok {
+		return "", errors.New("smtp: server doesn't support AUTH")
+	}
+	if err := client.Auth(smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPHost)); err != nil {
+		return "", err
+	}
+	if err := client.Mail(cfg.SMTPUsername); err != nil {
+		return "", err
+	}
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return "", err
+		}
+	}
+	reply, err := writeData(client, msg)
+	if err != nil {
+		return "", err
+	}
+	// The message is accepted at this point; a failed QUIT must not make the outbox send it again.
+	_ = client.Quit()
+	return reply, nil
+}
+
+// writeData runs the DATA exchange on the raw connection because client.Data discards the final
+// reply. It only runs once the session above is authenticated over TLS.
+func writeData(client *smtp.Client, msg []byte) (string, error) {
+	id, err := client.Text.Cmd("DATA")
+	if err != nil {
+		return "", err
+	}
+	client.Text.StartResponse(id)
+	_, _, err = client.Text.ReadResponse(354)
+	client.Text.EndResponse(id)
+	if err != nil {
+		return "", err
+	}
+	writer := client.Text.DotWriter()
+	if _, err := writer.Write(msg); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	code, reply, err := client.Text.ReadResponse(250)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d %s", code, reply), nil
+}
@@ -0,0 +1,88 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+const (
+	TemplateBirthday = "birthday"
+	// TemplateEvent renders every other event type unless a template named after the type exists.
+	TemplateEvent  = "event"
+	TemplateDigest = "digest"
+	// TemplateMilestone is sent to the extra recipients of milestone rules.
+	TemplateMilestone = "milestone"
+	// TemplateCollection invites subscribers to a gift collection.
+	TemplateCollection = "collection"
+	// TemplateCard compiles the greeting card board for the birthday person.
+	TemplateCard = "card"
+)
+
+// Templates renders message subjects and bodies. Every template file defines
+// a "subject" and a "body" template.
+type Templates struct {
+	byName map[string]*template.Template
+}
+
+// LoadTemplates parses the built-in templates; files in dir with the same name override them.
+func LoadTemplates(dir string) (*Templates, error) {
+	templates := &Templates{byName: make(map[string]*template.Template)}
+	builtIn, err := fs.Sub(defaultTemplates, "templates")
+	if err != nil {
+		return nil, err
+	}
+	if err := templates.parseDir(builtIn); err != nil {
+		return nil, err
+	}
+	if dir != "" {
+		if err := templates.parseDir(os.DirFS(dir)); err != nil {
+			return nil, err
+		}
+	}
+	return templates, nil
+}
+
+func (t *Templates) parseDir(fsys fs.FS) error {
+	files, err := fs.Glob(fsys, "*.tmpl")
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		parsed, err := template.ParseFS(fsys, file)
+		if err != nil {
+			return fmt.Errorf("failed to parse template %s: %w", file, err)
+		}
+		t.byName[strings.TrimSuffix(file, ".tmpl")] = parsed
+	}
+	return nil
+}
+
+func (t *Templates) Has(name string) bool {
+	_, ok := t.byName[name]
+	return ok
+}
+
+func (t *Templates) Render(name string, data any) (subject, body string, err error) {
+	parsed, ok := t.byName[name]
+	if !ok {
+		return "", "", fmt.Errorf("template %q not found", name)
+	}
+	var buf bytes.Buffer
+	if err := parsed.ExecuteTemplate(&buf, "subject", data); err != nil {
+		return "", "", err
+	}
+	subject = strings.TrimSpace(buf.String())
+	buf.Reset()
+	if err := parsed.ExecuteTemplate(&buf, "body", data); err != nil {
+		return "", "", err
+	}
+	return subject, strings.TrimSpace(buf.String()), nil
+}
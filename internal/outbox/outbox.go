@@ -0,0 +1,184 @@
+package outbox
+
+import (
+	"birthday-service/internal/config"
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"birthday-service/internal/notification"
+	"context"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+type Repository interface {
+	Enqueue(ctx context.Context, messages []entities.OutboxMessage) (int, error)
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]entities.OutboxMessage, error)
+	MarkSent(ctx context.Context, id int64, response string) error
+	MarkFailed(ctx context.Context, id int64, lastError, response string, nextAttempt time.Time) error
+	MarkDead(ctx context.Context, id int64, lastError, response string) error
+	MarkBounced(ctx context.Context, id int64, lastError, response string) error
+	RecordSkipped(ctx context.Context, attempts []entities.NotificationAttempt) error
+}
+
+// Sink writes planned notifications into the outbox, one row per recipient.
+type Sink struct {
+	repo Repository
+}
+
+func NewSink(repo Repository) *Sink {
+	return &Sink{repo: repo}
+}
+
+func (s *Sink) Send(ctx context.Context, message notification.Message) error {
+	rows := make([]entities.OutboxMessage, 0, len(message.To))
+	for _, recipient := range message.To {
+		row := entities.OutboxMessage{
+			EmployeeID: message.EmployeeID,
+			Recipient:  recipient,
+			Subject:    message.Subject,
+			Body:       message.Body,
+			Headers:    message.Headers,
+			DedupKey:   message.Key + ":" + recipient,
+		}
+		for _, delivery := range message.Deliveries {
+			if delivery.Recipient == recipient {
+				row.Deliveries = append(row.Deliveries, delivery)
+			}
+		}
+		rows = append(rows, row)
+	}
+	_, err := s.repo.Enqueue(ctx, rows)
+	return err
+}
+
+// RecordSkips keeps skipped notifications in the history, once per skip key and recipient.
+func (s *Sink) RecordSkips(ctx context.Context, skips []notification.Skip) error {
+	attempts := make([]entities.NotificationAttempt, 0, len(skips))
+	for _, skip := range skips {
+		attempts = append(attempts, entities.NotificationAttempt{
+			EmployeeID: skip.EmployeeID,
+			Recipient:  skip.Recipient,
+			Reason:     skip.Reason,
+			DedupKey:   "skip:" + skip.Key + ":" + skip.Recipient,
+		})
+	}
+	return s.repo.RecordSkipped(ctx, attempts)
+}
+
+// Worker delivers outbox messages through sink, retrying failures with exponential backoff.
+type Worker struct {
+	repo Repository
+	sink notification.Sink
+	cfg  config.OutboxCfg
+	log  *slog.Logger
+}
+
+func NewWorker(repo Repository, sink notification.Sink, cfg config.OutboxCfg, log *slog.Logger) *Worker {
+	return &Worker{repo: repo, sink: sink, cfg: cfg, log: log}
+}
+
+// Run starts cfg.Workers delivery loops and blocks until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < w.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				processed, err := w.ProcessBatch(ctx)
+				if err != nil {
+					w.log.Error("failed to process outbox batch", errMsg.Err(err))
+				}
+				if processed > 0 {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(w.cfg.PollInterval):
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// ProcessBatch claims and delivers one batch and returns how many messages it handled.
+func (w *Worker) ProcessBatch(ctx context.Context) (int, error) {
+	messages, err := w.repo.Claim(ctx, w.cfg.BatchSize, w.cfg.Lease)
+	if err != nil {
+		return 0, err
+	}
+	for _, message := range messages {
+		w.deliver(ctx, message)
+	}
+	return len(messages), nil
+}
+
+func (w *Worker) deliver(ctx context.Context, message entities.OutboxMessage) {
+	log := w.log.With(slog.Int64("outbox_id", message.ID), slog.Int("attempt", message.Attempts))
+
+	response, err := w.send(ctx, notification.Message{
+		EmployeeID: message.EmployeeID,
+		Key:        message.DedupKey,
+		To:         []string{message.Recipient},
+		Subject:    message.Subject,
+		Body:       message.Body,
+		Headers:    message.Headers,
+	})
+	if err == nil {
+		if err := w.repo.MarkSent(ctx, message.ID, response); err != nil {
+			log.Error("failed to mark message sent", errMsg.Err(err))
+		}
+		return
+	}
+
+	if notification.IsBounce(err) {
+		log.Error("message rejected by the mail server", errMsg.Err(err))
+		if err := w.repo.MarkBounced(ctx, message.ID, err.Error(), response); err != nil {
+			log.Error("failed to mark message bounced", errMsg.Err(err))
+		}
+		return
+	}
+
+	if message.Attempts >= w.cfg.MaxAttempts {
+		log.Error("message moved to dead letter", errMsg.Err(err))
+		if err := w.repo.MarkDead(ctx, message.ID, err.Error(), response); err != nil {
+			log.Error("failed to mark message dead", errMsg.Err(err))
+		}
+		return
+	}
+
+	nextAttempt := time.Now().Add(Backoff(message.Attempts, w.cfg.BaseBackoff, w.cfg.MaxBackoff))
+	log.Warn("message delivery failed, will retry", slog.Time("next_attempt_at", nextAttempt), errMsg.Err(err))
+	if err := w.repo.MarkFailed(ctx, message.ID, err.Error(), response, nextAttempt); err != nil {
+		log.Error("failed to reschedule message", errMsg.Err(err))
+	}
+}
+
+// send delivers through the sink, keeping the provider's response when the sink reports one.
+func (w *Worker) send(ctx context.Context, message notification.Message) (string, error) {
+	if transport, ok := w.sink.(notification.Transport); ok {
+		return transport.Deliver(ctx, message)
+	}
+	return "", w.sink.Send(ctx, message)
+}
+
+// Backoff returns base*2^(attempt-1) capped at max, with "equal jitter" so that
+// messages failing together do not retry in lockstep.
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + time.Duration(rand.Int63n(int64(half)))
+}
@@ -0,0 +1,123 @@
+package outbox
+
+import (
+	"birthday-service/internal/config"
+	"birthday-service/internal/entities"
+	"birthday-service/internal/notification"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/textproto"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt int
+		base    time.Duration
+		max     time.Duration
+		want    time.Duration
+	}{
+		{name: "first attempt", attempt: 1, base: time.Second, max: time.Minute, want: time.Second},
+		{name: "doubles", attempt: 3, base: time.Second, max: time.Minute, want: 4 * time.Second},
+		{name: "capped", attempt: 10, base: time.Second, max: time.Minute, want: time.Minute},
+		{name: "zero attempt", attempt: 0, base: time.Second, max: time.Minute, want: time.Second},
+		{name: "base above max", attempt: 1, base: time.Hour, max: time.Minute, want: time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				got := Backoff(tt.attempt, tt.base, tt.max)
+				if got < tt.want/2 || got >= tt.want {
+					t.Fatalf("Backoff(%d) = %v, want in [%v, %v)", tt.attempt, got, tt.want/2, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBackoffWithoutJitter(t *testing.T) {
+	if got := Backoff(1, time.Nanosecond, time.Nanosecond); got != time.Nanosecond {
+		t.Fatalf("Backoff = %v, want %v", got, time.Nanosecond)
+	}
+}
+
+type fakeRepo struct {
+	Repository
+	claimed     []entities.OutboxMessage
+	status      string
+	nextAttempt time.Time
+}
+
+func (f *fakeRepo) Claim(context.Context, int, time.Duration) ([]entities.OutboxMessage, error) {
+	claimed := f.claimed
+	f.claimed = nil
+	return claimed, nil
+}
+
+func (f *fakeRepo) MarkSent(context.Context, int64, string) error {
+	f.status = "sent"
+	return nil
+}
+
+func (f *fakeRepo) MarkFailed(_ context.Context, _ int64, _, _ string, nextAttempt time.Time) error {
+	f.status = "failed"
+	f.nextAttempt = nextAttempt
+	return nil
+}
+
+func (f *fakeRepo) MarkDead(context.Context, int64, string, string) error {
+	f.status = "dead"
+	return nil
+}
+
+func (f *fakeRepo) MarkBounced(context.Context, int64, string, string) error {
+	f.status = "bounced"
+	return nil
+}
+
+type fakeSink struct {
+	err error
+}
+
+func (f fakeSink) Send(context.Context, notification.Message) error {
+	return f.err
+}
+
+func TestWorkerDeliver(t *testing.T) {
+	cfg := config.OutboxCfg{BatchSize: 10, MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour}
+	tests := []struct {
+		name     string
+		attempts int
+		err      error
+		want     string
+	}{
+		{name: "delivered", attempts: 1, want: "sent"},
+		{name: "temporary failure is retried", attempts: 1, err: errors.New("connection reset"), want: "failed"},
+		{name: "last retry before the limit", attempts: 2, err: errors.New("connection reset"), want: "failed"},
+		{name: "dead after max attempts", attempts: 3, err: errors.New("connection reset"), want: "dead"},
+		{name: "bounce is not retried", attempts: 1, err: &textproto.Error{Code: 550, Msg: "no such user"}, want: "bounced"},
+		{name: "temporary reply is retried", attempts: 1, err: &textproto.Error{Code: 451, Msg: "try later"}, want: "failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepo{claimed: []entities.OutboxMessage{{ID: 1, Recipient: "a@example.com", Attempts: tt.attempts}}}
+			worker := NewWorker(repo, fakeSink{err: tt.err}, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			before := time.Now()
+			processed, err := worker.ProcessBatch(context.Background())
+			if err != nil || processed != 1 {
+				t.Fatalf("ProcessBatch = %d, %v", processed, err)
+			}
+			if repo.status != tt.want {
+				t.Fatalf("status = %q, want %q", repo.status, tt.want)
+			}
+			if tt.want == "failed" && !repo.nextAttempt.After(before) {
+				t.Fatalf("next attempt %v is not in the future", repo.nextAttempt)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package privacy
+
+import (
+	"birthday-service/internal/entities"
+	"time"
+)
+
+const (
+	VisibilityFull     = "full"
+	VisibilityDayMonth = "day_month"
+	VisibilityHidden   = "hidden"
+
+	// DayMonthLayout is the ISO 8601 date without a year used wherever only the day and month are shown.
+	DayMonthLayout = "--01-02"
+)
+
+func ValidVisibility(visibility string) bool {
+	return visibility == VisibilityFull || visibility == VisibilityDayMonth || visibility == VisibilityHidden
+}
+
+type EmployeeView struct {
+	ID               int        `json:"employee_id"`
+	Name             string     `json:"name"`
+	Birthday         *time.Time `json:"birthday,omitempty"`
+	BirthdayDayMonth string     `json:"birthday_day_month,omitempty"`
+	ExternalID       string     `json:"external_id,omitempty"`
+	ManagerID        int        `json:"manager_id,omitempty"`
+	Email            string     `json:"email,omitempty"`
+	Visibility       string     `json:"birthday_visibility,omitempty"`
+	OptOut           bool       `json:"notifications_opt_out,omitempty"`
+	ArchivedAt       *time.Time `json:"archived_at,omitempty"`
+}
+
+// Effective returns the visibility a viewer gets; admins always see the full date.
+func Effective(employee entities.Employee, admin bool) string {
+	if admin || employee.Visibility == "" {
+		return VisibilityFull
+	}
+	return employee.Visibility
+}
+
+func View(employee entities.Employee, admin bool) EmployeeView {
+	view := EmployeeView{
+		ID:         employee.ID,
+		Name:       employee.Name,
+		ExternalID: employee.ExternalID,
+		ManagerID:  employee.ManagerID,
+		ArchivedAt: employee.ArchivedAt,
+	}
+	if admin {
+		view.Email = employee.Email
+		view.Visibility = employee.Visibility
+		view.OptOut = employee.OptOut
+	}
+	switch Effective(employee, admin) {
+	case VisibilityFull:
+		birthday := employee.Birthday
+		view.Birthday = &birthday
+	case VisibilityDayMonth:
+		view.BirthdayDayMonth = employee.Birthday.Format(DayMonthLayout)
+	}
+	return view
+}
+
+func Views(employees []entities.Employee, admin bool) []EmployeeView {
+	views := make([]EmployeeView, 0, len(employees))
+	for _, employee := range employees {
+		views = append(views, View(employee, admin))
+	}
+	return views
+}
+
+// FormatBirthday renders the birthday with fullLayout or dayMonthLayout depending on
+// visibility and returns "" when the date must not be shown at all.
+func FormatBirthday(employee entities.Employee, admin bool, fullLayout, dayMonthLayout string) string {
+	switch Effective(employee, admin) {
+	case VisibilityFull:
+		return employee.Birthday.Format(fullLayout)
+	case VisibilityDayMonth:
+		return employee.Birthday.Format(dayMonthLayout)
+	}
+	return ""
+}
+
+// Email returns the employee's email for admins only; other users get "".
+func Email(employee entities.Employee, admin bool) string {
+	if !admin {
+		return ""
+	}
+	return employee.Email
+}
+
+// SubscriptionView hides the subscribed employee's birthday the same way View does.
+func SubscriptionView(sub entities.SubscriptionDetails, admin bool) entities.SubscriptionDetails {
+	if sub.Birthday == nil {
+		return sub
+	}
+	employee := entities.Employee{Birthday: *sub.Birthday, Visibility: sub.Visibility}
+	switch Effective(employee, admin) {
+	case VisibilityDayMonth:
+		sub.DayMonth = sub.Birthday.Format(DayMonthLayout)
+		sub.Birthday = nil
+	case VisibilityHidden:
+		sub.Birthday = nil
+	}
+	return sub
+}
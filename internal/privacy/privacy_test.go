@@ -0,0 +1,109 @@
+package privacy
+
+import (
+	"birthday-service/internal/entities"
+	"testing"
+	"time"
+)
+
+var birthday = time.Date(1990, time.March, 14, 0, 0, 0, 0, time.UTC)
+
+func employee(visibility string) entities.Employee {
+	return entities.Employee{ID: 1, Name: "Anna", Birthday: birthday, Email: "anna@example.com", Visibility: visibility}
+}
+
+func TestEffective(t *testing.T) {
+	tests := []struct {
+		visibility string
+		admin      bool
+		want       string
+	}{
+		{visibility: "", want: VisibilityFull},
+		{visibility: VisibilityFull, want: VisibilityFull},
+		{visibility: VisibilityDayMonth, want: VisibilityDayMonth},
+		{visibility: VisibilityHidden, want: VisibilityHidden},
+		{visibility: VisibilityDayMonth, admin: true, want: VisibilityFull},
+		{visibility: VisibilityHidden, admin: true, want: VisibilityFull},
+	}
+	for _, tt := range tests {
+		if got := Effective(employee(tt.visibility), tt.admin); got != tt.want {
+			t.Errorf("Effective(%q, admin=%v) = %q, want %q", tt.visibility, tt.admin, got, tt.want)
+		}
+	}
+}
+
+func TestView(t *testing.T) {
+	tests := []struct {
+		name         string
+		visibility   string
+		admin        bool
+		wantBirthday bool
+		wantDayMonth string
+		wantEmail    string
+	}{
+		{name: "full", visibility: VisibilityFull, wantBirthday: true},
+		{name: "day and month", visibility: VisibilityDayMonth, wantDayMonth: "--03-14"},
+		{name: "hidden", visibility: VisibilityHidden},
+		{name: "admin sees hidden", visibility: VisibilityHidden, admin: true, wantBirthday: true, wantEmail: "anna@example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := View(employee(tt.visibility), tt.admin)
+			if (view.Birthday != nil) != tt.wantBirthday {
+				t.Fatalf("Birthday = %v, want shown = %v", view.Birthday, tt.wantBirthday)
+			}
+			if view.Birthday != nil && !view.Birthday.Equal(birthday) {
+				t.Fatalf("Birthday = %v, want %v", view.Birthday, birthday)
+			}
+			if view.BirthdayDayMonth != tt.wantDayMonth {
+				t.Fatalf("BirthdayDayMonth = %q, want %q", view.BirthdayDayMonth, tt.wantDayMonth)
+			}
+			if view.Email != tt.wantEmail {
+				t.Fatalf("Email = %q, want %q", view.Email, tt.wantEmail)
+			}
+			if !tt.admin && (view.Visibility != "" || view.OptOut) {
+				t.Fatalf("settings leaked to a non-admin: %+v", view)
+			}
+		})
+	}
+}
+
+func TestFormatBirthday(t *testing.T) {
+	tests := []struct {
+		visibility string
+		admin      bool
+		want       string
+	}{
+		{visibility: VisibilityFull, want: "1990-03-14"},
+		{visibility: VisibilityDayMonth, want: "--03-14"},
+		{visibility: VisibilityHidden, want: ""},
+		{visibility: VisibilityHidden, admin: true, want: "1990-03-14"},
+	}
+	for _, tt := range tests {
+		got := FormatBirthday(employee(tt.visibility), tt.admin, "2006-01-02", DayMonthLayout)
+		if got != tt.want {
+			t.Errorf("FormatBirthday(%q, admin=%v) = %q, want %q", tt.visibility, tt.admin, got, tt.want)
+		}
+	}
+}
+
+func TestSubscriptionView(t *testing.T) {
+	tests := []struct {
+		visibility   string
+		admin        bool
+		wantBirthday bool
+		wantDayMonth string
+	}{
+		{visibility: VisibilityFull, wantBirthday: true},
+		{visibility: VisibilityDayMonth, wantDayMonth: "--03-14"},
+		{visibility: VisibilityHidden},
+		{visibility: VisibilityHidden, admin: true, wantBirthday: true},
+	}
+	for _, tt := range tests {
+		date := birthday
+		sub := SubscriptionView(entities.SubscriptionDetails{ID: 1, Birthday: &date, Visibility: tt.visibility}, tt.admin)
+		if (sub.Birthday != nil) != tt.wantBirthday || sub.DayMonth != tt.wantDayMonth {
+			t.Errorf("SubscriptionView(%q, admin=%v) = %v, %q", tt.visibility, tt.admin, sub.Birthday, sub.DayMonth)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Package schedule answers when a user should get mail: their local date, quiet hours and
+// preferred delivery hour, all in the user's own time zone.
+package schedule
+
+import (
+	"birthday-service/internal/entities"
+	"errors"
+	"sync"
+	"time"
+)
+
+// locations caches time zones by name: loading one reads the zoneinfo database.
+var locations sync.Map
+
+// LoadLocation returns the IANA time zone with the given name. "Local" is rejected because it
+// depends on the server the service runs on.
+func LoadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	if name == "Local" {
+		return nil, errors.New("unknown time zone Local")
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// Location returns the user's time zone, falling back to UTC for empty or unknown names.
+func Location(user entities.User) *time.Location {
+	if user.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := LoadLocation(user.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// LocalDate returns the user's calendar date at t as a UTC midnight.
+func LocalDate(user entities.User, t time.Time) time.Time {
+	local := t.In(Location(user))
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// BeforeDeliveryHour reports whether t is earlier in the user's local day than the preferred delivery hour.
+func BeforeDeliveryHour(user entities.User, t time.Time) bool {
+	return user.DeliveryHour != nil && t.In(Location(user)).Hour() < *user.DeliveryHour
+}
+
+// InQuietHours reports whether t falls into the user's local quiet hours; the window may wrap midnight.
+func InQuietHours(user entities.User, t time.Time) bool {
+	if user.QuietHoursStart == nil || user.QuietHoursEnd == nil || *user.QuietHoursStart == *user.QuietHoursEnd {
+		return false
+	}
+	start, end, hour := *user.QuietHoursStart, *user.QuietHoursEnd, t.In(Location(user)).Hour()
+	if start < end {
+		return hour >= start && hour < end
+	}
+	return hour >= start || hour < end
+}
@@ -0,0 +1,94 @@
+package schedule
+
+import (
+	"birthday-service/internal/entities"
+	"testing"
+	"time"
+)
+
+func hour(h int) *int {
+	return &h
+}
+
+func TestLoadLocation(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "Europe/Moscow"},
+		{name: "UTC"},
+		{name: "Local", wantErr: true},
+		{name: "Mars/Olympus", wantErr: true},
+	}
+	for _, tt := range tests {
+		_, err := LoadLocation(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("LoadLocation(%q) error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestLocalDate(t *testing.T) {
+	// 22:30 UTC on March 14 is already March 15 in Novosibirsk (UTC+7).
+	now := time.Date(2026, time.March, 14, 22, 30, 0, 0, time.UTC)
+	tests := []struct {
+		timezone string
+		want     time.Time
+	}{
+		{timezone: "", want: time.Date(2026, time.March, 14, 0, 0, 0, 0, time.UTC)},
+		{timezone: "Asia/Novosibirsk", want: time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{timezone: "America/New_York", want: time.Date(2026, time.March, 14, 0, 0, 0, 0, time.UTC)},
+		{timezone: "Invalid/Zone", want: time.Date(2026, time.March, 14, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := LocalDate(entities.User{Timezone: tt.timezone}, now); !got.Equal(tt.want) {
+			t.Errorf("LocalDate(%q) = %v, want %v", tt.timezone, got, tt.want)
+		}
+	}
+}
+
+func TestBeforeDeliveryHour(t *testing.T) {
+	now := time.Date(2026, time.March, 14, 5, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		user entities.User
+		want bool
+	}{
+		{name: "any time", user: entities.User{}, want: false},
+		{name: "too early", user: entities.User{DeliveryHour: hour(9)}, want: true},
+		{name: "on the hour", user: entities.User{DeliveryHour: hour(5)}, want: false},
+		{name: "local time is later", user: entities.User{Timezone: "Asia/Novosibirsk", DeliveryHour: hour(9)}, want: false},
+	}
+	for _, tt := range tests {
+		if got := BeforeDeliveryHour(tt.user, now); got != tt.want {
+			t.Errorf("%s: BeforeDeliveryHour = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestInQuietHours(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end *int
+		timezone   string
+		at         int
+		want       bool
+	}{
+		{name: "not set", at: 3, want: false},
+		{name: "empty window", start: hour(8), end: hour(8), at: 8, want: false},
+		{name: "inside daytime window", start: hour(12), end: hour(14), at: 13, want: true},
+		{name: "end is exclusive", start: hour(12), end: hour(14), at: 14, want: false},
+		{name: "wraps midnight, late evening", start: hour(22), end: hour(7), at: 23, want: true},
+		{name: "wraps midnight, early morning", start: hour(22), end: hour(7), at: 6, want: true},
+		{name: "wraps midnight, daytime", start: hour(22), end: hour(7), at: 12, want: false},
+		// 20:00 UTC is 03:00 in Novosibirsk.
+		{name: "local time", start: hour(22), end: hour(7), timezone: "Asia/Novosibirsk", at: 20, want: true},
+	}
+	for _, tt := range tests {
+		user := entities.User{Timezone: tt.timezone, QuietHoursStart: tt.start, QuietHoursEnd: tt.end}
+		at := time.Date(2026, time.March, 14, tt.at, 0, 0, 0, time.UTC)
+		if got := InQuietHours(user, at); got != tt.want {
+			t.Errorf("%s: InQuietHours = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
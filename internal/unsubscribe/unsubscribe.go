@@ -0,0 +1,78 @@
+package unsubscribe
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// AllSubscriptions in a token means the recipient opts out of every notification.
+const AllSubscriptions = 0
+
+var ErrInvalidToken = errors.New("invalid unsubscribe token")
+
+// Links builds and verifies HMAC-signed unsubscribe links, so recipients can opt out
+// without logging in.
+type Links struct {
+	secret  []byte
+	baseURL string
+}
+
+func NewLinks(secret, baseURL string) *Links {
+	return &Links{secret: []byte(secret), baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// URL returns the public unsubscribe link for a subscription, or for all notifications
+// when subscriptionID is AllSubscriptions.
+func (l *Links) URL(userID, subscriptionID int) string {
+	return l.baseURL + "/unsubscribe?token=" + url.QueryEscape(l.Token(userID, subscriptionID))
+}
+
+// BaseURL is the public address of the service the links point to.
+func (l *Links) BaseURL() string {
+	return l.baseURL
+}
+
+func (l *Links) Token(userID, subscriptionID int) string {
+	payload := fmt.Sprintf("%d:%d", userID, subscriptionID)
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+		base64.RawURLEncoding.EncodeToString(l.sign(payload))
+}
+
+func (l *Links) Parse(token string) (userID, subscriptionID int, err error) {
+	encodedPayload, encodedMAC, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, 0, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return 0, 0, ErrInvalidToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil || !hmac.Equal(mac, l.sign(string(payload))) {
+		return 0, 0, ErrInvalidToken
+	}
+
+	userPart, subPart, ok := strings.Cut(string(payload), ":")
+	if !ok {
+		return 0, 0, ErrInvalidToken
+	}
+	if userID, err = strconv.Atoi(userPart); err != nil {
+		return 0, 0, ErrInvalidToken
+	}
+	if subscriptionID, err = strconv.Atoi(subPart); err != nil {
+		return 0, 0, ErrInvalidToken
+	}
+	return userID, subscriptionID, nil
+}
+
+func (l *Links) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, l.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
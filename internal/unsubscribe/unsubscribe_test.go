@@ -0,0 +1,86 @@
+package unsubscribe
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestTokenRoundTrip(t *testing.T) {
+	links := NewLinks("secret", "https://birthdays.example.com/")
+	tests := []struct {
+		name           string
+		userID         int
+		subscriptionID int
+	}{
+		{name: "subscription", userID: 7, subscriptionID: 42},
+		{name: "all subscriptions", userID: 7, subscriptionID: AllSubscriptions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID, subscriptionID, err := links.Parse(links.Token(tt.userID, tt.subscriptionID))
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if userID != tt.userID || subscriptionID != tt.subscriptionID {
+				t.Fatalf("Parse = %d, %d, want %d, %d", userID, subscriptionID, tt.userID, tt.subscriptionID)
+			}
+		})
+	}
+}
+
+func TestURL(t *testing.T) {
+	links := NewLinks("secret", "https://birthdays.example.com/")
+	link, err := url.Parse(links.URL(7, 42))
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+	if got := link.Scheme + "://" + link.Host + link.Path; got != "https://birthdays.example.com/unsubscribe" {
+		t.Fatalf("URL = %s", got)
+	}
+	if _, _, err := links.Parse(link.Query().Get("token")); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+}
+
+func TestParseRejectsTamperedTokens(t *testing.T) {
+	links := NewLinks("secret", "https://birthdays.example.com")
+	token := links.Token(7, 42)
+	payload, mac, _ := strings.Cut(token, ".")
+	forged := base64.RawURLEncoding.EncodeToString([]byte("8:42"))
+	flipped := []byte(mac)
+	if flipped[0] == 'A' {
+		flipped[0] = 'B'
+	} else {
+		flipped[0] = 'A'
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "empty", token: ""},
+		{name: "no signature", token: payload},
+		{name: "other user", token: forged + "." + mac},
+		{name: "changed signature", token: payload + "." + string(flipped)},
+		{name: "truncated signature", token: payload + "." + mac[:len(mac)-4]},
+		{name: "not base64", token: "!!!." + mac},
+		{name: "signed with another secret", token: NewLinks("rotated", "").Token(7, 42)},
+		{name: "signed payload without separator", token: signed(links, "742")},
+		{name: "signed payload with non-numeric ids", token: signed(links, "seven:42")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := links.Parse(tt.token); !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.token, err, ErrInvalidToken)
+			}
+		})
+	}
+}
+
+func signed(links *Links, payload string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+		base64.RawURLEncoding.EncodeToString(links.sign(payload))
+}
@@ -0,0 +1,30 @@
+package jwt
+
+import (
+	"birthday-service/api/response"
+	"birthday-service/internal/entities"
+	errMsg "birthday-service/internal/err"
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+// UserFinder looks up the account a token was issued for.
+type UserFinder interface {
+	FindUserByEmail(ctx context.Context, email string) (entities.User, error)
+}
+
+// CurrentUser loads the account of the authenticated request. When it cannot be found, a 404 is
+// rendered and false is returned, so the handler only has to return.
+func CurrentUser(w http.ResponseWriter, r *http.Request, log *slog.Logger, users UserFinder) (entities.User, bool) {
+	user, err := users.FindUserByEmail(r.Context(), EmailFromContext(r.Context()))
+	if err != nil {
+		log.Error("Failed to find current user", errMsg.Err(err))
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, response.Error("user not found"))
+		return entities.User{}, false
+	}
+	return user, true
+}
@@ -24,9 +24,15 @@ func NewJWTManager(secret string, log *slog.Logger) *JWTManager {
 	return &JWTManager{secret: []byte(secret), log: log}
 }
 
-func (manager *JWTManager) GenerateToken(email string, expiration time.Duration) (string, error) {
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+func (manager *JWTManager) GenerateToken(email string, role string, expiration time.Duration) (string, error) {
 	claims := jwt.MapClaims{
 		"email": email,
+		"role":  role,
 		"exp":   time.Now().Add(expiration).Unix(),
 	}
 
@@ -2,11 +2,40 @@ package jwt
 
 import (
 	"birthday-service/api/response"
+	"context"
 	"github.com/go-chi/render"
 	"net/http"
 	"strings"
 )
 
+type ctxKey int
+
+const (
+	emailKey ctxKey = iota
+	roleKey
+)
+
+func EmailFromContext(ctx context.Context) string {
+	email, _ := ctx.Value(emailKey).(string)
+	return email
+}
+
+func IsAdmin(ctx context.Context) bool {
+	role, _ := ctx.Value(roleKey).(string)
+	return role == RoleAdmin
+}
+
+func withClaims(r *http.Request, claims map[string]interface{}) *http.Request {
+	ctx := r.Context()
+	if email, ok := claims["email"].(string); ok {
+		ctx = context.WithValue(ctx, emailKey, email)
+	}
+	if role, ok := claims["role"].(string); ok {
+		ctx = context.WithValue(ctx, roleKey, role)
+	}
+	return r.WithContext(ctx)
+}
+
 func TokenAuthMiddleware(jwtManager *JWTManager, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		tokenString := r.Header.Get("Authorization")
@@ -21,12 +50,12 @@ func TokenAuthMiddleware(jwtManager *JWTManager, next http.Handler) http.Handler
 			return
 		}
 
-		_, err := jwtManager.VerifyToken(token[1])
+		claims, err := jwtManager.VerifyToken(token[1])
 		if err != nil {
 			render.JSON(w, r, response.Error("unauthorized"))
 			return
 		}
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, withClaims(r, claims))
 	})
 }
 
@@ -51,11 +80,11 @@ func TokenAuthAndRoleMiddleware(jwtManager *JWTManager, next http.Handler) http.
 		}
 
 		role, ok := claims["role"].(string)
-		if !ok || role != "admin" {
+		if !ok || role != RoleAdmin {
 			render.JSON(w, r, response.Error("forbidden"))
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, withClaims(r, claims))
 	})
 }